@@ -11,6 +11,7 @@ require (
 	github.com/casbin/casbin/v2 v2.51.0
 	github.com/go-sql-driver/mysql v1.6.0
 	github.com/google/uuid v1.3.0
+	github.com/mattn/go-sqlite3 v1.14.16
 	github.com/opentracing/opentracing-go v1.2.0
 	github.com/paulmach/orb v0.7.1
 	github.com/satori/go.uuid v1.2.1-0.20181028125025-b2ce2384e17b
@@ -217,6 +217,8 @@ type typeQueue struct {
 	t  reflect.Type
 	sf *StructField
 	pp string // parent path
+	// the struct types of the ancestors, including current struct
+	ancestors []reflect.Type
 }
 
 func getCodec(t reflect.Type, tagName string, fmtFunc FormatFunc) *Struct {
@@ -224,7 +226,7 @@ func getCodec(t reflect.Type, tagName string, fmtFunc FormatFunc) *Struct {
 
 	root := &StructField{}
 	queue := []typeQueue{}
-	queue = append(queue, typeQueue{Deref(t), root, ""})
+	queue = append(queue, typeQueue{Deref(t), root, "", []reflect.Type{Deref(t)}})
 
 	for len(queue) > 0 {
 		q := queue[0]
@@ -271,8 +273,8 @@ func getCodec(t reflect.Type, tagName string, fmtFunc FormatFunc) *Struct {
 			sf.embed = ft.Kind() == reflect.Struct && f.Anonymous
 
 			if ft.Kind() == reflect.Struct {
-				// check recursive, prevent infinite loop
-				if q.t == ft {
+				// check recursive, prevent infinite loop, eg. `A -> B -> A`
+				if isRecursive(q.ancestors, ft) {
 					goto nextStep
 				}
 
@@ -285,7 +287,8 @@ func getCodec(t reflect.Type, tagName string, fmtFunc FormatFunc) *Struct {
 					// queue = append(queue, typeQueue{ft, sf, path})
 				}
 
-				queue = append(queue, typeQueue{ft, sf, path})
+				ancestors := append(make([]reflect.Type, 0, len(q.ancestors)+1), q.ancestors...)
+				queue = append(queue, typeQueue{ft, sf, path, append(ancestors, ft)})
 			}
 
 		nextStep:
@@ -336,6 +339,15 @@ func getCodec(t reflect.Type, tagName string, fmtFunc FormatFunc) *Struct {
 	return codec
 }
 
+func isRecursive(ancestors []reflect.Type, t reflect.Type) bool {
+	for _, at := range ancestors {
+		if at == t {
+			return true
+		}
+	}
+	return false
+}
+
 func appendSlice(s []int, i int) []int {
 	x := make([]int, len(s)+1)
 	copy(x, s)
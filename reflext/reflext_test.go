@@ -14,6 +14,16 @@ type recursiveStruct struct {
 	Recursive *recursiveStruct
 }
 
+type mutualStruct struct {
+	Name   string
+	Mutual *mutualRefStruct
+}
+
+type mutualRefStruct struct {
+	Name   string
+	Mutual *mutualStruct
+}
+
 type tagStruct struct {
 	ID   int64  `sqlike:"id,omitempty,default=40"`
 	Skip string `sqlike:"-"`
@@ -136,4 +146,13 @@ func TestCodec(t *testing.T) {
 		require.NotNil(t, codec.names["Name"])
 		require.NotNil(t, codec.names["Recursive"])
 	}
+
+	{
+		typeof = reflect.TypeOf(mutualStruct{})
+		codec = getCodec(typeof, "sqlike", nil)
+
+		require.Equal(t, len(codec.fields), 4)
+		require.Equal(t, len(codec.properties), 2)
+		require.NotNil(t, codec.names["Mutual.Mutual"])
+	}
 }
@@ -12,6 +12,7 @@ import (
 	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
 	"github.com/RevenueMonster/sqlike/sql/util"
 	"github.com/RevenueMonster/sqlike/sqlike/actions"
+	"github.com/RevenueMonster/sqlike/sqlike/columns"
 	"github.com/RevenueMonster/sqlike/sqlike/indexes"
 	"github.com/RevenueMonster/sqlike/sqlike/options"
 )
@@ -24,6 +25,19 @@ type SQLDialect interface {
 	Format(v interface{}) (val string)
 }
 
+// InsertIDMode : how the generated value of `auto_increment` column is returned on insert
+type InsertIDMode int
+
+// insert id modes :
+const (
+	// FirstInsertID : `LastInsertId` is the generated value of the first inserted record, eg. mysql
+	FirstInsertID InsertIDMode = iota
+	// LastInsertID : `LastInsertId` is the generated value of the last inserted record, eg. sqlite
+	LastInsertID
+	// ReturningInsertID : the generated values are returned as rows using `RETURNING` clause, eg. postgres
+	ReturningInsertID
+)
+
 // Dialect :
 type Dialect interface {
 	SQLDialect
@@ -46,14 +60,28 @@ type Dialect interface {
 	GetIndexes(stmt sqlstmt.Stmt, db, table string)
 	CreateIndexes(stmt sqlstmt.Stmt, db, table string, idxs []indexes.Index, supportDesc bool)
 	DropIndexes(stmt sqlstmt.Stmt, db, table string, idxs []string)
+	GetForeignKeys(stmt sqlstmt.Stmt, db, table string)
+	AddForeignKeys(stmt sqlstmt.Stmt, db, table string, fks []indexes.ForeignKey)
+	DropForeignKeys(stmt sqlstmt.Stmt, db, table string, names []string)
 	CreateTable(stmt sqlstmt.Stmt, db, table, pk string, info driver.Info, fields []reflext.StructFielder) (err error)
+	ColumnSchema(info driver.Info, sf reflext.StructFielder) (columns.Column, error)
 	AlterTable(stmt sqlstmt.Stmt, db, table, pk string, hasPk bool, info driver.Info, fields []reflext.StructFielder, columns util.StringSlice, indexes util.StringSlice, unsafe bool) (err error)
 	InsertInto(stmt sqlstmt.Stmt, db, table, pk string, mapper reflext.StructMapper, codec codec.Codecer, fields []reflext.StructFielder, values reflect.Value, opts *options.InsertOptions) (err error)
+	InsertIDMode() InsertIDMode
 	Select(stmt sqlstmt.Stmt, act *actions.FindActions, mode options.LockMode) (err error)
 	Update(stmt sqlstmt.Stmt, act *actions.UpdateActions) (err error)
 	Delete(stmt sqlstmt.Stmt, act *actions.DeleteActions) (err error)
 	SelectStmt(stmt sqlstmt.Stmt, query interface{}) (err error)
 	Replace(stmt sqlstmt.Stmt, db, table string, columns []string, query *sql.SelectStmt) (err error)
+	IsRetryableError(err error) bool
+}
+
+// MigrationReverter : the optional interface of the dialect to revert the migration, eg. the down script of `MigrateStatements`
+type MigrationReverter interface {
+	// ModifyColumn : restore the column definition, the column will be placed after the `after` column or first if it's empty
+	ModifyColumn(stmt sqlstmt.Stmt, db, table string, col columns.Column, after string)
+	// DropUniqueIndex : drop the unique index or primary key which is added by `AlterTable`
+	DropUniqueIndex(stmt sqlstmt.Stmt, db, table, name string)
 }
 
 var (
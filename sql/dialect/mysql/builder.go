@@ -67,7 +67,13 @@ func (b mySQLBuilder) SetRegistryAndBuilders(rg codec.Codecer, blr *sqlstmt.Stat
 	blr.SetBuilder(reflect.TypeOf(primitive.Sort{}), b.BuildSort)
 	blr.SetBuilder(reflect.TypeOf(primitive.KV{}), b.BuildKeyValue)
 	blr.SetBuilder(reflect.TypeOf(primitive.Math{}), b.BuildMath)
+	blr.SetBuilder(reflect.TypeOf(primitive.Inserted{}), b.BuildInserted)
 	blr.SetBuilder(reflect.TypeOf(&primitive.Case{}), b.BuildCase)
+	blr.SetBuilder(reflect.TypeOf(primitive.Join{}), b.BuildJoin)
+	blr.SetBuilder(reflect.TypeOf(primitive.CTE{}), b.BuildCTE)
+	blr.SetBuilder(reflect.TypeOf(primitive.WindowFunc{}), b.BuildWindowFunc)
+	blr.SetBuilder(reflect.TypeOf(&primitive.Window{}), b.BuildWindow)
+	blr.SetBuilder(reflect.TypeOf(primitive.Over{}), b.BuildOver)
 	blr.SetBuilder(reflect.TypeOf(spatial.Func{}), b.BuildSpatialFunc)
 	blr.SetBuilder(reflect.TypeOf(&sql.SelectStmt{}), b.BuildSelectStmt)
 	blr.SetBuilder(reflect.TypeOf(&sql.UpdateStmt{}), b.BuildUpdateStmt)
@@ -404,6 +410,13 @@ func (b *mySQLBuilder) BuildMath(stmt sqlstmt.Stmt, it interface{}) (err error)
 	return
 }
 
+// BuildInserted :
+func (b *mySQLBuilder) BuildInserted(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Inserted)
+	stmt.WriteString("VALUES(" + b.Quote(x.Field) + ")")
+	return nil
+}
+
 // BuildCase :
 func (b *mySQLBuilder) BuildCase(stmt sqlstmt.Stmt, it interface{}) error {
 	x := it.(*primitive.Case)
@@ -430,6 +443,157 @@ func (b *mySQLBuilder) BuildCase(stmt sqlstmt.Stmt, it interface{}) error {
 	return nil
 }
 
+// BuildJoin :
+func (b *mySQLBuilder) BuildJoin(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Join)
+	stmt.WriteString(x.Type.String() + " ")
+	if err := b.builder.BuildStatement(stmt, x.Table); err != nil {
+		return err
+	}
+	if len(x.Using) > 0 {
+		stmt.WriteString(" USING (")
+		for i, col := range x.Using {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			stmt.WriteString(b.Quote(col))
+		}
+		stmt.WriteByte(')')
+		return nil
+	}
+	if len(x.On.Values) > 0 {
+		stmt.WriteString(" ON ")
+		if err := b.builder.BuildStatement(stmt, x.On); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BuildCTE :
+func (b *mySQLBuilder) BuildCTE(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.CTE)
+	stmt.WriteString(b.Quote(x.Name))
+	if len(x.Columns) > 0 {
+		stmt.WriteString(" (")
+		for i, col := range x.Columns {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			stmt.WriteString(b.Quote(col))
+		}
+		stmt.WriteByte(')')
+	}
+	stmt.WriteString(" AS (")
+	if err := b.builder.BuildStatement(stmt, x.Anchor); err != nil {
+		return err
+	}
+	if x.Recursion != nil {
+		stmt.WriteString(" UNION ALL ")
+		if err := b.builder.BuildStatement(stmt, x.Recursion); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildWindowFunc :
+func (b *mySQLBuilder) BuildWindowFunc(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.WindowFunc)
+	stmt.WriteString(x.Type.String())
+	stmt.WriteByte('(')
+	for i, arg := range x.Args {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		if err := b.builder.BuildStatement(stmt, arg); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildWindow :
+func (b *mySQLBuilder) BuildWindow(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(*primitive.Window)
+	stmt.WriteByte('(')
+	if x.Base != "" {
+		stmt.WriteString(b.Quote(x.Base))
+	}
+	if len(x.Partitions) > 0 {
+		if x.Base != "" {
+			stmt.WriteByte(' ')
+		}
+		stmt.WriteString("PARTITION BY ")
+		for i, field := range x.Partitions {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			if err := b.builder.BuildStatement(stmt, field); err != nil {
+				return err
+			}
+		}
+	}
+	if len(x.Sorts) > 0 {
+		if x.Base != "" || len(x.Partitions) > 0 {
+			stmt.WriteByte(' ')
+		}
+		stmt.WriteString("ORDER BY ")
+		for i, sort := range x.Sorts {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			if err := b.builder.BuildStatement(stmt, sort); err != nil {
+				return err
+			}
+		}
+	}
+	if x.Frame != nil {
+		if x.Base != "" || len(x.Partitions) > 0 || len(x.Sorts) > 0 {
+			stmt.WriteByte(' ')
+		}
+		stmt.WriteString(x.Frame.Unit.String() + " BETWEEN ")
+		if err := b.builder.BuildStatement(stmt, x.Frame.Start); err != nil {
+			return err
+		}
+		stmt.WriteString(" AND ")
+		if err := b.builder.BuildStatement(stmt, x.Frame.End); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildOver :
+func (b *mySQLBuilder) BuildOver(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Over)
+	// `COALESCE` is not allowed in between `SUM` and `OVER`
+	if agg, ok := x.Field.(primitive.Aggregate); ok && agg.By == primitive.Sum {
+		stmt.WriteString("SUM(")
+		if err := b.getValue(stmt, agg.Field); err != nil {
+			return err
+		}
+		stmt.WriteByte(')')
+	} else if err := b.builder.BuildStatement(stmt, x.Field); err != nil {
+		return err
+	}
+	stmt.WriteString(" OVER ")
+	switch vi := x.Window.(type) {
+	case string:
+		stmt.WriteString(b.Quote(vi))
+	case *primitive.Window:
+		if err := b.BuildWindow(stmt, vi); err != nil {
+			return err
+		}
+	default:
+		stmt.WriteString("()")
+	}
+	return nil
+}
+
 // BuildSpatialFunc :
 func (b *mySQLBuilder) BuildSpatialFunc(stmt sqlstmt.Stmt, it interface{}) (err error) {
 	x := it.(spatial.Func)
@@ -550,6 +714,9 @@ func (b *mySQLBuilder) BuildTypeSafe(stmt sqlstmt.Stmt, it interface{}) (err err
 // BuildSelectStmt :
 func (b *mySQLBuilder) BuildSelectStmt(stmt sqlstmt.Stmt, it interface{}) error {
 	x := it.(*sql.SelectStmt)
+	if err := b.appendWith(stmt, x.CTEs); err != nil {
+		return err
+	}
 	stmt.WriteString("SELECT ")
 	if x.DistinctOn {
 		stmt.WriteString("DISTINCT ")
@@ -561,12 +728,18 @@ func (b *mySQLBuilder) BuildSelectStmt(stmt sqlstmt.Stmt, it interface{}) error
 	if err := b.appendTable(stmt, x.Tables); err != nil {
 		return err
 	}
+	if err := b.appendJoins(stmt, x.Joins); err != nil {
+		return err
+	}
 	if err := b.appendWhere(stmt, x.Conditions.Values); err != nil {
 		return err
 	}
 	if err := b.appendGroupBy(stmt, x.Groups); err != nil {
 		return err
 	}
+	if err := b.appendWindow(stmt, x.Windows); err != nil {
+		return err
+	}
 	if err := b.appendOrderBy(stmt, x.Sorts); err != nil {
 		return err
 	}
@@ -577,6 +750,9 @@ func (b *mySQLBuilder) BuildSelectStmt(stmt sqlstmt.Stmt, it interface{}) error
 // BuildUpdateStmt :
 func (b *mySQLBuilder) BuildUpdateStmt(stmt sqlstmt.Stmt, it interface{}) error {
 	x := it.(*sql.UpdateStmt)
+	if err := b.appendWith(stmt, x.CTEs); err != nil {
+		return err
+	}
 	stmt.WriteString("UPDATE " + b.TableName(x.Database, x.Table) + ` `)
 	if err := b.appendSet(stmt, x.Values); err != nil {
 		return err
@@ -598,6 +774,9 @@ func (b *mySQLBuilder) BuildFindActions(stmt sqlstmt.Stmt, it interface{}) error
 	if x.Table == "" {
 		return errors.New("mysql: empty table name")
 	}
+	if err := b.appendWith(stmt, x.CTEs); err != nil {
+		return err
+	}
 	stmt.WriteString("SELECT ")
 	if x.DistinctOn {
 		stmt.WriteString("DISTINCT ")
@@ -605,7 +784,16 @@ func (b *mySQLBuilder) BuildFindActions(stmt sqlstmt.Stmt, it interface{}) error
 	if err := b.appendSelect(stmt, x.Projections); err != nil {
 		return err
 	}
-	stmt.WriteString(" FROM " + b.TableName(x.Database, x.Table))
+	stmt.WriteString(" FROM ")
+	if hasCTE(x.CTEs, x.Table) {
+		// common table expression can't be qualified with database name
+		stmt.WriteString(b.Quote(x.Table))
+	} else {
+		stmt.WriteString(b.TableName(x.Database, x.Table))
+	}
+	if err := b.appendJoins(stmt, x.Joins); err != nil {
+		return err
+	}
 	if err := b.appendWhere(stmt, x.Conditions.Values); err != nil {
 		return err
 	}
@@ -716,6 +904,48 @@ func (b *mySQLBuilder) appendTable(stmt sqlstmt.Stmt, fields []interface{}) erro
 	return nil
 }
 
+func (b *mySQLBuilder) appendWith(stmt sqlstmt.Stmt, ctes []primitive.CTE) error {
+	if len(ctes) == 0 {
+		return nil
+	}
+	stmt.WriteString("WITH ")
+	for _, cte := range ctes {
+		if cte.Recursive {
+			stmt.WriteString("RECURSIVE ")
+			break
+		}
+	}
+	for i, cte := range ctes {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		if err := b.builder.BuildStatement(stmt, cte); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(' ')
+	return nil
+}
+
+func hasCTE(ctes []primitive.CTE, name string) bool {
+	for _, cte := range ctes {
+		if cte.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *mySQLBuilder) appendJoins(stmt sqlstmt.Stmt, joins []interface{}) error {
+	for _, j := range joins {
+		stmt.WriteByte(' ')
+		if err := b.builder.BuildStatement(stmt, j); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (b *mySQLBuilder) appendWhere(stmt sqlstmt.Stmt, conds []interface{}) error {
 	length := len(conds)
 	if length > 0 {
@@ -745,6 +975,24 @@ func (b *mySQLBuilder) appendGroupBy(stmt sqlstmt.Stmt, fields []interface{}) er
 	return nil
 }
 
+func (b *mySQLBuilder) appendWindow(stmt sqlstmt.Stmt, windows []primitive.NamedWindow) error {
+	length := len(windows)
+	if length < 1 {
+		return nil
+	}
+	stmt.WriteString(" WINDOW ")
+	for i := 0; i < length; i++ {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteString(b.Quote(windows[i].Name) + " AS ")
+		if err := b.BuildWindow(stmt, windows[i].Window); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (b *mySQLBuilder) appendOrderBy(stmt sqlstmt.Stmt, sorts []interface{}) error {
 	length := len(sorts)
 	if length < 1 {
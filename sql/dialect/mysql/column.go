@@ -1,6 +1,11 @@
 package mysql
 
-import sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+import (
+	"strings"
+
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/columns"
+)
 
 // GetColumns :
 func (ms *MySQL) GetColumns(stmt sqlstmt.Stmt, dbName, table string) {
@@ -22,3 +27,49 @@ func (ms *MySQL) DropColumn(stmt sqlstmt.Stmt, db, table, column string) {
 	stmt.WriteString(" DROP COLUMN " + ms.Quote(column))
 	stmt.WriteByte(';')
 }
+
+// ModifyColumn : the column definition is the one returned by `GetColumns`
+func (ms *MySQL) ModifyColumn(stmt sqlstmt.Stmt, db, table string, col columns.Column, after string) {
+	stmt.WriteString("ALTER TABLE " + ms.TableName(db, table))
+	stmt.WriteString(" MODIFY COLUMN " + ms.Quote(col.Name) + " " + col.Type)
+	if col.Charset != nil {
+		stmt.WriteString(" CHARACTER SET " + *col.Charset)
+	}
+	if col.Collation != nil {
+		stmt.WriteString(" COLLATE " + *col.Collation)
+	}
+	if col.Nullable {
+		stmt.WriteString(" NULL")
+	} else {
+		stmt.WriteString(" NOT NULL")
+	}
+
+	// `DEFAULT_GENERATED` means the default value is an expression, eg. CURRENT_TIMESTAMP
+	extra := strings.TrimSpace(strings.Replace(col.Extra, "DEFAULT_GENERATED", "", 1))
+	if col.DefaultValue != nil {
+		dflt := *col.DefaultValue
+		switch {
+		case strings.Contains(col.Extra, "DEFAULT_GENERATED"):
+			if !strings.HasPrefix(strings.ToUpper(dflt), "CURRENT_TIMESTAMP") {
+				dflt = "(" + dflt + ")"
+			}
+		// mariadb returns the quoted literal
+		case strings.HasPrefix(dflt, "'"):
+		default:
+			dflt = "'" + strings.ReplaceAll(dflt, "'", "''") + "'"
+		}
+		stmt.WriteString(" DEFAULT " + dflt)
+	}
+	if extra != "" {
+		stmt.WriteString(" " + extra)
+	}
+	if col.Comment != "" {
+		stmt.WriteString(" COMMENT '" + strings.ReplaceAll(col.Comment, "'", "''") + "'")
+	}
+	if after == "" {
+		stmt.WriteString(" FIRST")
+	} else {
+		stmt.WriteString(" AFTER " + ms.Quote(after))
+	}
+	stmt.WriteByte(';')
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/columns"
 	"github.com/stretchr/testify/require"
 )
 
@@ -34,3 +35,45 @@ func TestDropColumn(t *testing.T) {
 	require.Equal(t, "ALTER TABLE `db`.`table` DROP COLUMN `c1`;", stmt.String())
 	require.ElementsMatch(t, []interface{}{}, stmt.Args())
 }
+
+func TestModifyColumn(t *testing.T) {
+	ms := New()
+	charset, collation, dflt := "utf8mb4", "utf8mb4_unicode_ci", "it's"
+	stmt := sqlstmt.AcquireStmt(ms)
+	defer sqlstmt.ReleaseStmt(stmt)
+	ms.ModifyColumn(stmt, "db", "table", columns.Column{
+		Name:         "Name",
+		Type:         "VARCHAR(191)",
+		DefaultValue: &dflt,
+		Charset:      &charset,
+		Collation:    &collation,
+		Comment:      "user's name",
+	}, "ID")
+	require.Equal(t, "ALTER TABLE `db`.`table` MODIFY COLUMN `Name` VARCHAR(191) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'it''s' COMMENT 'user''s name' AFTER `ID`;", stmt.String())
+
+	stmt.Reset()
+	now := "CURRENT_TIMESTAMP(6)"
+	ms.ModifyColumn(stmt, "db", "table", columns.Column{
+		Name:         "UpdatedAt",
+		Type:         "DATETIME(6)",
+		DefaultValue: &now,
+		Extra:        "DEFAULT_GENERATED on update CURRENT_TIMESTAMP(6)",
+	}, "")
+	require.Equal(t, "ALTER TABLE `db`.`table` MODIFY COLUMN `UpdatedAt` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) on update CURRENT_TIMESTAMP(6) FIRST;", stmt.String())
+
+	stmt.Reset()
+	ms.ModifyColumn(stmt, "db", "table", columns.Column{Name: "Age", Type: "INT(11)", Nullable: true}, "Name")
+	require.Equal(t, "ALTER TABLE `db`.`table` MODIFY COLUMN `Age` INT(11) NULL AFTER `Name`;", stmt.String())
+}
+
+func TestDropUniqueIndex(t *testing.T) {
+	ms := New()
+	stmt := sqlstmt.AcquireStmt(ms)
+	defer sqlstmt.ReleaseStmt(stmt)
+	ms.DropUniqueIndex(stmt, "db", "table", "UX_Email")
+	require.Equal(t, "ALTER TABLE `db`.`table` DROP INDEX `UX_Email`;", stmt.String())
+
+	stmt.Reset()
+	ms.DropUniqueIndex(stmt, "db", "table", "PRIMARY")
+	require.Equal(t, "ALTER TABLE `db`.`table` DROP PRIMARY KEY;", stmt.String())
+}
@@ -0,0 +1,24 @@
+package mysql
+
+import (
+	"errors"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+)
+
+// error numbers of mysql which the transaction can be retried
+const (
+	errLockWaitTimeout uint16 = 1205
+	errLockDeadlock    uint16 = 1213
+)
+
+// IsRetryableError : deadlock rolls back the whole transaction, while lock wait timeout only rolls back the statement
+// (unless `innodb_rollback_on_timeout` is enabled), it's still safe to be retried as `RunInTransaction` rolls back
+// the transaction on error before the whole transaction is run again
+func (ms MySQL) IsRetryableError(err error) bool {
+	var me *mysqldriver.MySQLError
+	if !errors.As(err, &me) {
+		return false
+	}
+	return me.Number == errLockDeadlock || me.Number == errLockWaitTimeout
+}
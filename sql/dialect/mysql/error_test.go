@@ -0,0 +1,19 @@
+package mysql
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	ms := New()
+	require.True(t, ms.IsRetryableError(&mysqldriver.MySQLError{Number: 1213}))
+	require.True(t, ms.IsRetryableError(fmt.Errorf("insert: %w", &mysqldriver.MySQLError{Number: 1205})))
+	require.False(t, ms.IsRetryableError(&mysqldriver.MySQLError{Number: 1062}))
+	require.False(t, ms.IsRetryableError(errors.New("deadlock")))
+	require.False(t, ms.IsRetryableError(nil))
+}
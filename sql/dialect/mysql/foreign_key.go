@@ -0,0 +1,68 @@
+package mysql
+
+import (
+	"strings"
+
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/indexes"
+)
+
+// GetForeignKeys :
+func (ms MySQL) GetForeignKeys(stmt sqlstmt.Stmt, db, table string) {
+	stmt.WriteString(`SELECT kcu.CONSTRAINT_NAME, kcu.COLUMN_NAME, kcu.REFERENCED_TABLE_NAME, kcu.REFERENCED_COLUMN_NAME, rc.DELETE_RULE, rc.UPDATE_RULE
+	FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu
+	INNER JOIN INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS rc ON rc.CONSTRAINT_SCHEMA = kcu.CONSTRAINT_SCHEMA AND rc.CONSTRAINT_NAME = kcu.CONSTRAINT_NAME
+	WHERE kcu.TABLE_SCHEMA = ? AND kcu.TABLE_NAME = ? ORDER BY kcu.CONSTRAINT_NAME, kcu.ORDINAL_POSITION;`)
+	stmt.AppendArgs(db, table)
+}
+
+// AddForeignKeys :
+func (ms MySQL) AddForeignKeys(stmt sqlstmt.Stmt, db, table string, fks []indexes.ForeignKey) {
+	if len(fks) == 0 {
+		return
+	}
+	stmt.WriteString("ALTER TABLE " + ms.TableName(db, table) + " ")
+	for i, fk := range fks {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteString("ADD ")
+		ms.buildForeignKey(stmt, db, table, fk)
+	}
+	stmt.WriteByte(';')
+}
+
+// DropForeignKeys :
+func (ms MySQL) DropForeignKeys(stmt sqlstmt.Stmt, db, table string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	stmt.WriteString("ALTER TABLE " + ms.TableName(db, table) + " ")
+	for i, name := range names {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteString("DROP FOREIGN KEY " + ms.Quote(name))
+	}
+	stmt.WriteByte(';')
+}
+
+func (ms MySQL) buildForeignKey(stmt sqlstmt.Stmt, db, table string, fk indexes.ForeignKey) {
+	stmt.WriteString("CONSTRAINT " + ms.Quote(fk.GetName(table)))
+	stmt.WriteString(" FOREIGN KEY (" + ms.quoteAll(fk.Columns) + ")")
+	stmt.WriteString(" REFERENCES " + ms.TableName(db, fk.RefTable) + " (" + ms.quoteAll(fk.RefColumns) + ")")
+	if fk.OnDelete != "" {
+		stmt.WriteString(" ON DELETE " + fk.OnDelete)
+	}
+	if fk.OnUpdate != "" {
+		stmt.WriteString(" ON UPDATE " + fk.OnUpdate)
+	}
+}
+
+func (ms MySQL) quoteAll(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = ms.Quote(n)
+	}
+	return strings.Join(quoted, ",")
+}
@@ -0,0 +1,42 @@
+package mysql
+
+import (
+	"testing"
+
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/indexes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForeignKey(t *testing.T) {
+	ms := New()
+	stmt := sqlstmt.AcquireStmt(ms)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	t.Run("GetForeignKeys", func(it *testing.T) {
+		defer stmt.Reset()
+		ms.GetForeignKeys(stmt, "db", "Order")
+		require.Contains(it, stmt.String(), "FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu")
+		require.ElementsMatch(it, []interface{}{"db", "Order"}, stmt.Args())
+	})
+
+	t.Run("AddForeignKeys", func(it *testing.T) {
+		defer stmt.Reset()
+		ms.AddForeignKeys(stmt, "db", "Order", nil)
+		require.Empty(it, stmt.String())
+
+		ms.AddForeignKeys(stmt, "db", "Order", []indexes.ForeignKey{
+			{Columns: []string{"UserID"}, RefTable: "User", RefColumns: []string{"ID"}, OnDelete: indexes.Cascade},
+			{Name: "fk_merchant", Columns: []string{"MerchantID", "StoreID"}, RefTable: "Store", RefColumns: []string{"MerchantID", "ID"}, OnUpdate: indexes.SetNull},
+		})
+		require.Equal(it, "ALTER TABLE `db`.`Order` "+
+			"ADD CONSTRAINT `FK_Order_UserID` FOREIGN KEY (`UserID`) REFERENCES `db`.`User` (`ID`) ON DELETE CASCADE,"+
+			"ADD CONSTRAINT `fk_merchant` FOREIGN KEY (`MerchantID`,`StoreID`) REFERENCES `db`.`Store` (`MerchantID`,`ID`) ON UPDATE SET NULL;", stmt.String())
+	})
+
+	t.Run("DropForeignKeys", func(it *testing.T) {
+		defer stmt.Reset()
+		ms.DropForeignKeys(stmt, "db", "Order", []string{"FK_Order_UserID", "fk_merchant"})
+		require.Equal(it, "ALTER TABLE `db`.`Order` DROP FOREIGN KEY `FK_Order_UserID`,DROP FOREIGN KEY `fk_merchant`;", stmt.String())
+	})
+}
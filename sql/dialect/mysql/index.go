@@ -135,3 +135,13 @@ func (ms MySQL) getIndexByType(k indexes.Type) (idx string) {
 	}
 	return
 }
+
+// DropUniqueIndex :
+func (ms MySQL) DropUniqueIndex(stmt sqlstmt.Stmt, db, table, name string) {
+	stmt.WriteString("ALTER TABLE " + ms.TableName(db, table))
+	if name == "PRIMARY" {
+		stmt.WriteString(" DROP PRIMARY KEY;")
+		return
+	}
+	stmt.WriteString(" DROP INDEX " + ms.Quote(name) + ";")
+}
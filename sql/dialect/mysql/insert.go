@@ -7,8 +7,11 @@ import (
 	"github.com/RevenueMonster/sqlike/reflext"
 	"github.com/RevenueMonster/sqlike/spatial"
 	"github.com/RevenueMonster/sqlike/sql/codec"
+	"github.com/RevenueMonster/sqlike/sql/dialect"
 	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sql/util"
 	"github.com/RevenueMonster/sqlike/sqlike/options"
+	"github.com/RevenueMonster/sqlike/sqlike/primitive"
 )
 
 // InsertInto :
@@ -82,56 +85,123 @@ func (ms MySQL) InsertInto(stmt sqlstmt.Stmt, db, table, pk string, cache reflex
 		stmt.WriteByte(')')
 	}
 
-	var (
-		column string
-		name   string
-	)
 	if opt.Mode == options.InsertOnDuplicate {
 		stmt.WriteString(" ON DUPLICATE KEY UPDATE ")
-		next := false
-		for _, f := range fields {
-			name = f.Name()
-			// skip primary key on duplicate update
-			if name == pk {
-				continue
+		if len(opt.OnConflict) > 0 {
+			if err := ms.appendOnConflict(stmt, fields, opt.OnConflict); err != nil {
+				return err
 			}
-
-			// skip primary key on duplicate update
-			if _, ok := f.Tag().LookUp("primary_key"); ok {
-				continue
+		} else {
+			columns := updateColumns(fields, pk, omitField, opt.Keeps)
+			// keep all the existing values, mysql requires at least one assignment
+			if len(columns) == 0 && len(fields) > 0 {
+				column := ms.Quote(fields[0].Name())
+				stmt.WriteString(column + "=" + column)
 			}
-
-			if _, ok := f.Tag().LookUp("auto_increment"); ok {
-				continue
+			for i, name := range columns {
+				if i > 0 {
+					stmt.WriteByte(',')
+				}
+				column := ms.Quote(name)
+				stmt.WriteString(column + "=VALUES(" + column + ")")
 			}
+		}
+	}
+	stmt.WriteByte(';')
+	return
+}
 
-			// skip omit fields on update
-			if _, ok := omitField[name]; ok {
-				continue
-			}
+// InsertIDMode : the generated values of multiple records are consecutive, and `LastInsertId` is the first one
+func (ms MySQL) InsertIDMode() dialect.InsertIDMode {
+	return dialect.FirstInsertID
+}
 
-			if next {
-				stmt.WriteByte(',')
+func (ms MySQL) appendOnConflict(stmt sqlstmt.Stmt, fields []reflext.StructFielder, values []interface{}) error {
+	for i, v := range values {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		switch vi := v.(type) {
+		case string:
+			if !hasField(fields, vi) {
+				return fmt.Errorf("mysql: invalid on conflict column %q", vi)
 			}
-
-			column = ms.Quote(name)
+			column := ms.Quote(vi)
 			stmt.WriteString(column + "=VALUES(" + column + ")")
-			next = true
+		case primitive.KV:
+			if err := ms.parser.BuildStatement(stmt, vi); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("mysql: invalid on conflict value %T", v)
 		}
 	}
-	stmt.WriteByte(';')
-	return
+	return nil
 }
 
-func findEncoder(c codec.Codecer, sf reflext.StructFielder, v reflect.Value) (codec.ValueEncoder, error) {
-	// auto_increment field should pass nil if it's empty
-	if _, ok := sf.Tag().LookUp("auto_increment"); ok && reflext.IsZero(v) {
-		return codec.NilEncoder, nil
+// updateColumns : columns to update on conflict, primary key, auto increment, auto create time, omitted and kept columns will be skipped
+func updateColumns(fields []reflext.StructFielder, pk string, omitField map[string]bool, keeps util.StringSlice) []string {
+	columns := make([]string, 0, len(fields))
+	for _, f := range fields {
+		name := f.Name()
+		// skip primary key on duplicate update
+		if name == pk {
+			continue
+		}
+
+		// skip primary key on duplicate update
+		if _, ok := f.Tag().LookUp("primary_key"); ok {
+			continue
+		}
+
+		if _, ok := f.Tag().LookUp("auto_increment"); ok {
+			continue
+		}
+
+		// keep the created time of the existing record
+		if _, ok := f.Tag().LookUp("auto_create_time"); ok {
+			continue
+		}
+
+		// skip omit fields on update
+		if _, ok := omitField[name]; ok {
+			continue
+		}
+
+		// keep existing value on update
+		if keeps.IndexOf(name) > -1 {
+			continue
+		}
+
+		columns = append(columns, name)
 	}
+	return columns
+}
+
+func hasField(fields []reflext.StructFielder, name string) bool {
+	for _, f := range fields {
+		if f.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func findEncoder(c codec.Codecer, sf reflext.StructFielder, v reflect.Value) (codec.ValueEncoder, error) {
 	encoder, err := c.LookupEncoder(v)
 	if err != nil {
 		return nil, err
 	}
+	// auto_increment field should pass nil if it's empty, it's checked on every record
+	// as the encoder is only looked up using the first record
+	if _, ok := sf.Tag().LookUp("auto_increment"); ok {
+		return func(sf reflext.StructFielder, v reflect.Value) (interface{}, error) {
+			if reflext.IsZero(v) {
+				return nil, nil
+			}
+			return encoder(sf, v)
+		}, nil
+	}
 	return encoder, nil
 }
 
@@ -0,0 +1,113 @@
+package mysql
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/codec"
+	"github.com/RevenueMonster/sqlike/sql/expr"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertInto(t *testing.T) {
+	type counter struct {
+		ID      string `sqlike:",primary_key"`
+		Name    string
+		Counter int
+	}
+
+	var (
+		ms     = New()
+		fields = reflext.DefaultMapper.CodecByType(reflect.TypeOf(counter{})).Properties()
+		v      = reflect.ValueOf([]counter{{ID: "1", Name: "a", Counter: 2}})
+	)
+
+	insertInto := func(opt *options.InsertOptions) (sqlstmt.Stmt, error) {
+		stmt := sqlstmt.NewStatement(ms)
+		err := ms.InsertInto(stmt, "db", "t", "$Key", reflext.DefaultMapper, codec.DefaultRegistry, fields, v, opt)
+		return stmt, err
+	}
+
+	t.Run("InsertIgnore", func(it *testing.T) {
+		stmt, err := insertInto(options.Insert().SetMode(options.InsertIgnore))
+		require.NoError(it, err)
+		require.Equal(it, "INSERT IGNORE INTO `db`.`t` (`ID`,`Name`,`Counter`) VALUES (?,?,?);", stmt.String())
+	})
+
+	t.Run("InsertOnDuplicate", func(it *testing.T) {
+		stmt, err := insertInto(options.Insert().SetMode(options.InsertOnDuplicate))
+		require.NoError(it, err)
+		require.Equal(it, "INSERT INTO `db`.`t` (`ID`,`Name`,`Counter`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `Name`=VALUES(`Name`),`Counter`=VALUES(`Counter`);", stmt.String())
+	})
+
+	t.Run("InsertOnDuplicate keeps auto_create_time", func(it *testing.T) {
+		type user struct {
+			ID        string `sqlike:",primary_key"`
+			Name      string
+			CreatedAt time.Time `sqlike:",auto_create_time"`
+			UpdatedAt time.Time `sqlike:",auto_update_time"`
+		}
+		stmt := sqlstmt.NewStatement(ms)
+		err := ms.InsertInto(
+			stmt, "db", "t", "$Key", reflext.DefaultMapper, codec.DefaultRegistry,
+			reflext.DefaultMapper.CodecByType(reflect.TypeOf(user{})).Properties(),
+			reflect.ValueOf([]user{{ID: "1", Name: "a"}}),
+			options.Insert().SetMode(options.InsertOnDuplicate),
+		)
+		require.NoError(it, err)
+		require.Equal(it, "INSERT INTO `db`.`t` (`ID`,`Name`,`CreatedAt`,`UpdatedAt`) VALUES (?,?,?,?) ON DUPLICATE KEY UPDATE `Name`=VALUES(`Name`),`UpdatedAt`=VALUES(`UpdatedAt`);", stmt.String())
+	})
+
+	t.Run("SetOnConflict with columns and expressions", func(it *testing.T) {
+		stmt, err := insertInto(options.Insert().SetOnConflict(
+			"Name",
+			expr.ColumnValue("Counter", expr.Add("Counter", expr.Values("Counter"))),
+		))
+		require.NoError(it, err)
+		require.Equal(it, "INSERT INTO `db`.`t` (`ID`,`Name`,`Counter`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `Name`=VALUES(`Name`),`Counter` = `Counter` + VALUES(`Counter`);", stmt.String())
+		require.Equal(it, []interface{}{"1", "a", int64(2)}, stmt.Args())
+	})
+
+	t.Run("SetOnConflict with value", func(it *testing.T) {
+		stmt, err := insertInto(options.Insert().SetOnConflict(expr.ColumnValue("Name", "b")))
+		require.NoError(it, err)
+		require.Equal(it, "INSERT INTO `db`.`t` (`ID`,`Name`,`Counter`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `Name` = ?;", stmt.String())
+		require.Equal(it, []interface{}{"1", "a", int64(2), "b"}, stmt.Args())
+	})
+
+	t.Run("SetOnConflict with invalid column", func(it *testing.T) {
+		_, err := insertInto(options.Insert().SetOnConflict("Unknown"))
+		require.Error(it, err)
+
+		_, err = insertInto(options.Insert().SetOnConflict(100))
+		require.Error(it, err)
+	})
+
+	t.Run("SetKeepOnConflict", func(it *testing.T) {
+		stmt, err := insertInto(options.Insert().SetKeepOnConflict("Name"))
+		require.NoError(it, err)
+		require.Equal(it, "INSERT INTO `db`.`t` (`ID`,`Name`,`Counter`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `Counter`=VALUES(`Counter`);", stmt.String())
+
+		stmt, err = insertInto(options.Insert().SetKeepOnConflict("Name", "Counter"))
+		require.NoError(it, err)
+		require.Equal(it, "INSERT INTO `db`.`t` (`ID`,`Name`,`Counter`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `ID`=`ID`;", stmt.String())
+	})
+
+	t.Run("AutoIncrement", func(it *testing.T) {
+		type user struct {
+			ID   int64 `sqlike:",auto_increment"`
+			Name string
+		}
+		fields := reflext.DefaultMapper.CodecByType(reflect.TypeOf(user{})).Properties()
+		v := reflect.ValueOf([]user{{Name: "a"}, {ID: 10, Name: "b"}})
+
+		stmt := sqlstmt.NewStatement(ms)
+		require.NoError(it, ms.InsertInto(stmt, "db", "t", "$Key", reflext.DefaultMapper, codec.DefaultRegistry, fields, v, options.Insert()))
+		require.Equal(it, "INSERT INTO `db`.`t` (`ID`,`Name`) VALUES (?,?),(?,?);", stmt.String())
+		require.Equal(it, []interface{}{nil, "a", int64(10), "b"}, stmt.Args())
+	})
+}
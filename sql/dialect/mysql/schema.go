@@ -127,12 +127,22 @@ func (s mySQLSchema) DateTimeDataType(sf reflext.StructFielder) (col columns.Col
 	col.Type = "DATETIME(" + size + ")"
 	col.Nullable = sf.IsNullable()
 	col.DefaultValue = &dflt
-	if _, ok := sf.Tag().LookUp("on_update"); ok {
+	if isOnUpdate(sf) {
 		col.Extra = "ON UPDATE " + dflt
 	}
 	return
 }
 
+// isOnUpdate : the column will be refreshed with current timestamp on update if it's tagged with `on_update` or `auto_update_time`
+func isOnUpdate(sf reflext.StructFielder) bool {
+	tag := sf.Tag()
+	if _, ok := tag.LookUp("on_update"); ok {
+		return true
+	}
+	_, ok := tag.LookUp("auto_update_time")
+	return ok
+}
+
 func (s mySQLSchema) JSONDataType(sf reflext.StructFielder) (col columns.Column) {
 	col.Name = sf.Name()
 	col.DataType = "JSON"
@@ -0,0 +1,32 @@
+package mysql
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateTimeDataType(t *testing.T) {
+	ms := New()
+	cdc := reflext.DefaultMapper.CodecByType(reflect.TypeOf(struct {
+		CreatedAt time.Time `sqlike:",auto_create_time"`
+		UpdatedAt time.Time `sqlike:",auto_update_time"`
+		SyncedAt  time.Time `sqlike:",on_update"`
+	}{}))
+	fields := cdc.Properties()
+
+	col, err := ms.ColumnSchema(nil, fields[0])
+	require.NoError(t, err)
+	require.Equal(t, "DATETIME(6)", col.Type)
+	require.Equal(t, "CURRENT_TIMESTAMP(6)", *col.DefaultValue)
+	require.Empty(t, col.Extra)
+
+	for _, sf := range fields[1:] {
+		col, err := ms.ColumnSchema(nil, sf)
+		require.NoError(t, err)
+		require.Equal(t, "ON UPDATE CURRENT_TIMESTAMP(6)", col.Extra)
+	}
+}
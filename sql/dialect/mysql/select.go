@@ -7,21 +7,50 @@ import (
 )
 
 // Select :
-func (ms *MySQL) Select(stmt sqlstmt.Stmt, f *actions.FindActions, lck options.LockMode) (err error) {
+func (ms *MySQL) Select(stmt sqlstmt.Stmt, f *actions.FindActions, mode options.LockMode) (err error) {
 	err = ms.parser.BuildStatement(stmt, f)
 	if err != nil {
 		return
 	}
-	switch lck {
+	lck := options.Lock{Mode: mode, Of: f.LockOf, Wait: f.LockWait}
+	switch lck.Mode {
 	case options.LockForUpdate:
 		stmt.WriteString(" FOR UPDATE")
+		ms.appendLockOption(stmt, lck)
 	case options.LockForRead:
-		stmt.WriteString(" LOCK IN SHARE MODE")
+		// `LOCK IN SHARE MODE` doesn't support `OF`, `NOWAIT` and `SKIP LOCKED`
+		if len(lck.Of) == 0 && lck.Wait == options.Wait {
+			stmt.WriteString(" LOCK IN SHARE MODE")
+			break
+		}
+		stmt.WriteString(" FOR SHARE")
+		ms.appendLockOption(stmt, lck)
+	case options.LockForShare:
+		stmt.WriteString(" FOR SHARE")
+		ms.appendLockOption(stmt, lck)
 	}
 	stmt.WriteByte(';')
 	return
 }
 
+func (ms *MySQL) appendLockOption(stmt sqlstmt.Stmt, lck options.Lock) {
+	if len(lck.Of) > 0 {
+		stmt.WriteString(" OF ")
+		for i, tb := range lck.Of {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			stmt.WriteString(ms.Quote(tb))
+		}
+	}
+	switch lck.Wait {
+	case options.NoWait:
+		stmt.WriteString(" NOWAIT")
+	case options.SkipLocked:
+		stmt.WriteString(" SKIP LOCKED")
+	}
+}
+
 // SelectStmt :
 func (ms *MySQL) SelectStmt(stmt sqlstmt.Stmt, query interface{}) (err error) {
 	err = ms.parser.BuildStatement(stmt, query)
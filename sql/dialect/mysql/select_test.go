@@ -8,6 +8,8 @@ import (
 	"github.com/RevenueMonster/sqlike/sql/expr"
 	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
 	"github.com/RevenueMonster/sqlike/sqlike/actions"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+	"github.com/RevenueMonster/sqlike/sqlike/primitive"
 	"github.com/stretchr/testify/require"
 )
 
@@ -92,3 +94,130 @@ func TestSelect(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func TestSelectJoin(t *testing.T) {
+	ms := New()
+
+	{
+		stmt := sqlstmt.NewStatement(ms)
+		err := ms.parser.BuildStatement(stmt, sql.Select().
+			From("db", "a").
+			Join("b", expr.Equal(expr.Column("a", "id"), expr.Column("b", "aid"))).
+			LeftJoin("c", expr.Using("code")).
+			CrossJoin("d").
+			Where(expr.Equal(expr.Column("a", "status"), "OK")).
+			Limit(10),
+		)
+		require.NoError(t, err)
+		require.Equal(t, "SELECT * FROM `db`.`a` INNER JOIN `b` ON `a`.`id` = `b`.`aid` LEFT JOIN `c` USING (`code`) CROSS JOIN `d` WHERE `a`.`status` = ? LIMIT 10", stmt.String())
+		require.ElementsMatch(t, []interface{}{"OK"}, stmt.Args())
+	}
+
+	{
+		stmt := sqlstmt.NewStatement(ms)
+		act := actions.Find().
+			RightJoin("b", expr.Equal(expr.Column("A", "id"), expr.Column("b", "aid"))).
+			Where(expr.Equal(expr.Column("b", "x"), 1)).(*actions.FindActions)
+		act.Database = "db"
+		act.Table = "A"
+		err := ms.parser.BuildStatement(stmt, act)
+		require.NoError(t, err)
+		require.Equal(t, "SELECT * FROM `db`.`A` RIGHT JOIN `b` ON `A`.`id` = `b`.`aid` WHERE `b`.`x` = ?", stmt.String())
+	}
+}
+
+func TestSelectWith(t *testing.T) {
+	ms := New()
+
+	{
+		stmt := sqlstmt.NewStatement(ms)
+		err := ms.parser.BuildStatement(stmt, sql.Select("ID").
+			With(sql.With("Active", sql.Select("ID").From("db", "User").Where(expr.Equal("Status", "ACTIVE")))).
+			From("Active"),
+		)
+		require.NoError(t, err)
+		require.Equal(t, "WITH `Active` AS (SELECT `ID` FROM `db`.`User` WHERE `Status` = ?) SELECT `ID` FROM `Active`", stmt.String())
+		require.ElementsMatch(t, []interface{}{"ACTIVE"}, stmt.Args())
+	}
+
+	{
+		stmt := sqlstmt.NewStatement(ms)
+		act := actions.Find().
+			With(
+				sql.WithRecursive("Tree",
+					sql.Select("ID", "Parent", expr.As(expr.Raw("0"), "Depth")).
+						From("db", "Category").
+						Where(expr.Equal("ID", 10)),
+					sql.Select(expr.Column("c", "ID"), expr.Column("c", "Parent"), expr.Raw("`t`.`Depth` + 1")).
+						From("db", "Category", expr.Raw("`c`")).
+						Join(expr.Raw("`Tree` AS `t`"), expr.Equal(expr.Column("t", "Parent"), expr.Column("c", "ID"))),
+				).WithColumns("ID", "Parent", "Depth"),
+			).
+			OrderBy(expr.Asc("Depth")).(*actions.FindActions)
+		act.Database = "db"
+		act.Table = "Tree"
+		err := ms.parser.BuildStatement(stmt, act)
+		require.NoError(t, err)
+		require.Equal(t, "WITH RECURSIVE `Tree` (`ID`,`Parent`,`Depth`) AS (SELECT `ID`,`Parent`,(0) AS `Depth` FROM `db`.`Category` WHERE `ID` = ? UNION ALL SELECT `c`.`ID`,`c`.`Parent`,`t`.`Depth` + 1 FROM `db`.`Category` `c` INNER JOIN `Tree` AS `t` ON `t`.`Parent` = `c`.`ID`) SELECT * FROM `Tree` ORDER BY `Depth`", stmt.String())
+		require.ElementsMatch(t, []interface{}{int64(10)}, stmt.Args())
+	}
+
+	{
+		stmt := sqlstmt.NewStatement(ms)
+		upd := &sql.UpdateStmt{
+			Database: "db",
+			Table:    "User",
+			Values:   []primitive.KV{expr.ColumnValue("Status", "INACTIVE")},
+		}
+		upd.With(sql.With("Expired", sql.Select("ID").From("db", "Session")))
+		err := ms.parser.BuildStatement(stmt, upd)
+		require.NoError(t, err)
+		require.Equal(t, "WITH `Expired` AS (SELECT `ID` FROM `db`.`Session`) UPDATE `db`.`User` SET `Status` = ?", stmt.String())
+	}
+}
+
+func TestSelectWindow(t *testing.T) {
+	ms := New()
+	stmt := sqlstmt.NewStatement(ms)
+	err := ms.parser.BuildStatement(stmt, sql.Select(
+		"ID",
+		expr.As(expr.Over(expr.RowNumber(), expr.Window().PartitionBy("Category").OrderBy(expr.Desc("Amount"))), "No"),
+		expr.As(expr.Over(expr.Lag("Amount", 1, 0), expr.Window("w")), "Prev"),
+		expr.As(expr.OverWindow(expr.Sum("Amount"), "w"), "Total"),
+		expr.As(expr.Over(expr.Average("Amount"), expr.Window().OrderBy(expr.Asc("CreatedAt")).Rows(expr.Preceding(6), expr.CurrentRow())), "Moving"),
+	).
+		From("db", "Sales").
+		Window("w", expr.Window().PartitionBy("Category").OrderBy(expr.Asc("CreatedAt"))).
+		OrderBy(expr.Asc("ID")),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT `ID`,(ROW_NUMBER() OVER (PARTITION BY `Category` ORDER BY `Amount` DESC)) AS `No`,(LAG(`Amount`,1,?) OVER (`w`)) AS `Prev`,(SUM(`Amount`) OVER `w`) AS `Total`,(AVG(`Amount`) OVER (ORDER BY `CreatedAt` ROWS BETWEEN 6 PRECEDING AND CURRENT ROW)) AS `Moving` FROM `db`.`Sales` WINDOW `w` AS (PARTITION BY `Category` ORDER BY `CreatedAt`) ORDER BY `ID`", stmt.String())
+	require.ElementsMatch(t, []interface{}{int64(0)}, stmt.Args())
+}
+
+func TestSelectLock(t *testing.T) {
+	act := actions.Find().From("db", "Job").
+		Where(expr.Equal("Status", "PENDING")).
+		Limit(10).(*actions.FindActions)
+
+	for _, tc := range []struct {
+		lock   options.Lock
+		suffix string
+	}{
+		{options.Lock{}, ""},
+		{options.Lock{Mode: options.LockForUpdate}, " FOR UPDATE"},
+		{options.Lock{Mode: options.LockForRead}, " LOCK IN SHARE MODE"},
+		{options.Lock{Mode: options.LockForShare}, " FOR SHARE"},
+		{options.Lock{Mode: options.LockForUpdate, Wait: options.SkipLocked}, " FOR UPDATE SKIP LOCKED"},
+		{options.Lock{Mode: options.LockForUpdate, Wait: options.NoWait}, " FOR UPDATE NOWAIT"},
+		{options.Lock{Mode: options.LockForRead, Wait: options.NoWait}, " FOR SHARE NOWAIT"},
+		{options.Lock{Mode: options.LockForUpdate, Of: []string{"Job", "j"}, Wait: options.SkipLocked}, " FOR UPDATE OF `Job`,`j` SKIP LOCKED"},
+		{options.Lock{Wait: options.SkipLocked}, ""},
+	} {
+		stmt := sqlstmt.NewStatement(MySQL{})
+		act.LockOf, act.LockWait = tc.lock.Of, tc.lock.Wait
+		err := New().Select(stmt, act, tc.lock.Mode)
+		require.NoError(t, err)
+		require.Equal(t, "SELECT * FROM `db`.`Job` WHERE `Status` = ? LIMIT 10"+tc.suffix+";", stmt.String())
+	}
+}
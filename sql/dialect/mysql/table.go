@@ -60,6 +60,11 @@ func (ms MySQL) CreateTable(stmt sqlstmt.Stmt, db, table, pk string, info driver
 		stored  bool
 	)
 
+	fks, err := indexes.ForeignKeys(fields)
+	if err != nil {
+		return err
+	}
+
 	stmt.WriteString("CREATE TABLE " + ms.TableName(db, table) + " ")
 	stmt.WriteByte('(')
 
@@ -147,6 +152,10 @@ func (ms MySQL) CreateTable(stmt sqlstmt.Stmt, db, table, pk string, info driver
 		stmt.WriteByte(',')
 		stmt.WriteString("PRIMARY KEY (" + ms.Quote(pkk.Name()) + ")")
 	}
+	for _, fk := range fks {
+		stmt.WriteByte(',')
+		ms.buildForeignKey(stmt, db, table, fk)
+	}
 	stmt.WriteByte(')')
 	stmt.WriteString(" ENGINE=INNODB")
 	code := string(info.Charset())
@@ -163,6 +172,11 @@ func (ms MySQL) CreateTable(stmt sqlstmt.Stmt, db, table, pk string, info driver
 	return
 }
 
+// ColumnSchema : the column definition of the struct field
+func (ms *MySQL) ColumnSchema(info driver.Info, sf reflext.StructFielder) (columns.Column, error) {
+	return ms.schema.GetColumn(info, sf)
+}
+
 // AlterTable :
 func (ms *MySQL) AlterTable(stmt sqlstmt.Stmt, db, table, pk string, hasPk bool, info driver.Info, fields []reflext.StructFielder, cols util.StringSlice, idxs util.StringSlice, unsafe bool) (err error) {
 	var (
@@ -0,0 +1,1100 @@
+package postgres
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/spatial"
+	"github.com/RevenueMonster/sqlike/sql"
+	"github.com/RevenueMonster/sqlike/sql/codec"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	sqlutil "github.com/RevenueMonster/sqlike/sql/util"
+	"github.com/RevenueMonster/sqlike/sqlike/actions"
+	"github.com/RevenueMonster/sqlike/sqlike/primitive"
+)
+
+var operatorMap = map[primitive.Operator]string{
+	primitive.Equal:          "=",
+	primitive.NotEqual:       "<>",
+	primitive.In:             "IN",
+	primitive.NotIn:          "NOT IN",
+	primitive.Between:        "BETWEEN",
+	primitive.NotBetween:     "NOT BETWEEN",
+	primitive.IsNull:         "IS NULL",
+	primitive.NotNull:        "IS NOT NULL",
+	primitive.GreaterThan:    ">",
+	primitive.GreaterOrEqual: ">=",
+	primitive.LesserThan:     "<",
+	primitive.LesserOrEqual:  "<=",
+	primitive.Or:             "OR",
+	primitive.And:            "AND",
+}
+
+type postgresBuilder struct {
+	registry codec.Codecer
+	builder  *sqlstmt.StatementBuilder
+	sqlutil.PostgresUtil
+}
+
+func (b postgresBuilder) SetRegistryAndBuilders(rg codec.Codecer, blr *sqlstmt.StatementBuilder) {
+	if rg == nil {
+		panic("missing required registry")
+	}
+	if blr == nil {
+		panic("missing required parser")
+	}
+	blr.SetBuilder(reflect.TypeOf(primitive.CastAs{}), b.BuildCastAs)
+	blr.SetBuilder(reflect.TypeOf(primitive.Func{}), b.BuildFunction)
+	blr.SetBuilder(reflect.TypeOf(primitive.JSONFunc{}), b.BuildJSONFunction)
+	blr.SetBuilder(reflect.TypeOf(primitive.Field{}), b.BuildField)
+	blr.SetBuilder(reflect.TypeOf(primitive.Value{}), b.BuildValue)
+	blr.SetBuilder(reflect.TypeOf(primitive.As{}), b.BuildAs)
+	blr.SetBuilder(reflect.TypeOf(primitive.Nil{}), b.BuildNil)
+	blr.SetBuilder(reflect.TypeOf(primitive.Raw{}), b.BuildRaw)
+	blr.SetBuilder(reflect.TypeOf(primitive.Encoding{}), b.BuildEncoding)
+	blr.SetBuilder(reflect.TypeOf(primitive.Aggregate{}), b.BuildAggregate)
+	blr.SetBuilder(reflect.TypeOf(primitive.Column{}), b.BuildColumn)
+	blr.SetBuilder(reflect.TypeOf(primitive.JSONColumn{}), b.BuildJSONColumn)
+	blr.SetBuilder(reflect.TypeOf(primitive.C{}), b.BuildClause)
+	blr.SetBuilder(reflect.TypeOf(primitive.L{}), b.BuildLike)
+	blr.SetBuilder(reflect.TypeOf(primitive.TypeSafe{}), b.BuildTypeSafe)
+	blr.SetBuilder(reflect.TypeOf(primitive.Operator(0)), b.BuildOperator)
+	blr.SetBuilder(reflect.TypeOf(primitive.Group{}), b.BuildGroup)
+	blr.SetBuilder(reflect.TypeOf(primitive.R{}), b.BuildRange)
+	blr.SetBuilder(reflect.TypeOf(primitive.Sort{}), b.BuildSort)
+	blr.SetBuilder(reflect.TypeOf(primitive.KV{}), b.BuildKeyValue)
+	blr.SetBuilder(reflect.TypeOf(primitive.Math{}), b.BuildMath)
+	blr.SetBuilder(reflect.TypeOf(primitive.Inserted{}), b.BuildInserted)
+	blr.SetBuilder(reflect.TypeOf(&primitive.Case{}), b.BuildCase)
+	blr.SetBuilder(reflect.TypeOf(primitive.Join{}), b.BuildJoin)
+	blr.SetBuilder(reflect.TypeOf(primitive.CTE{}), b.BuildCTE)
+	blr.SetBuilder(reflect.TypeOf(primitive.WindowFunc{}), b.BuildWindowFunc)
+	blr.SetBuilder(reflect.TypeOf(&primitive.Window{}), b.BuildWindow)
+	blr.SetBuilder(reflect.TypeOf(primitive.Over{}), b.BuildOver)
+	blr.SetBuilder(reflect.TypeOf(spatial.Func{}), b.BuildSpatialFunc)
+	blr.SetBuilder(reflect.TypeOf(&sql.SelectStmt{}), b.BuildSelectStmt)
+	blr.SetBuilder(reflect.TypeOf(&sql.UpdateStmt{}), b.BuildUpdateStmt)
+	blr.SetBuilder(reflect.TypeOf(&actions.FindActions{}), b.BuildFindActions)
+	blr.SetBuilder(reflect.TypeOf(&actions.UpdateActions{}), b.BuildUpdateActions)
+	blr.SetBuilder(reflect.TypeOf(&actions.DeleteActions{}), b.BuildDeleteActions)
+	blr.SetBuilder(reflect.String, b.BuildString)
+	b.registry = rg
+	b.builder = blr
+}
+
+// BuildCastAs :
+func (b *postgresBuilder) BuildCastAs(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.CastAs)
+	stmt.WriteString("CAST(")
+	if err := b.builder.BuildStatement(stmt, x.Value); err != nil {
+		return err
+	}
+	stmt.WriteString(" AS ")
+	switch x.DataType {
+	case primitive.JSON:
+		stmt.WriteString("JSONB")
+	case primitive.Varchar:
+		stmt.WriteString("VARCHAR")
+	case primitive.Char:
+		stmt.WriteString("CHAR")
+	case primitive.Date:
+		stmt.WriteString("DATE")
+	default:
+		return errors.New("postgres: unsupported cast as data type")
+	}
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildFunction :
+func (b *postgresBuilder) BuildFunction(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Func)
+	stmt.WriteString(x.Name)
+	stmt.WriteByte('(')
+	for i, args := range x.Args {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		if err := b.builder.BuildStatement(stmt, args); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildJSONFunction : json functions are mysql specific, we only support those which have the equivalent in postgres
+func (b *postgresBuilder) BuildJSONFunction(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.JSONFunc)
+	if x.Prefix != nil {
+		if err := b.getValue(stmt, x.Prefix); err != nil {
+			return err
+		}
+		stmt.WriteString(" ")
+	}
+	switch x.Type {
+	case primitive.JSON_QUOTE:
+		stmt.WriteString("TO_JSONB")
+	case primitive.JSON_TYPE:
+		stmt.WriteString("JSONB_TYPEOF")
+	case primitive.JSON_VALID:
+		return errors.New("postgres: unsupported json function JSON_VALID")
+	default:
+		stmt.WriteString(x.Type.String())
+	}
+	stmt.WriteByte('(')
+	for i, args := range x.Args {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		if err := b.builder.BuildStatement(stmt, args); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildString :
+func (b *postgresBuilder) BuildString(stmt sqlstmt.Stmt, it interface{}) error {
+	v := reflect.ValueOf(it)
+	stmt.WriteString(b.Quote(v.String()))
+	return nil
+}
+
+// BuildLike :
+func (b *postgresBuilder) BuildLike(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.L)
+	if err := b.builder.BuildStatement(stmt, x.Field); err != nil {
+		return err
+	}
+
+	stmt.WriteByte(' ')
+	if x.IsNot {
+		stmt.WriteString("NOT LIKE")
+	} else {
+		stmt.WriteString("LIKE")
+	}
+	stmt.WriteByte(' ')
+	v := reflext.ValueOf(x.Value)
+	if !v.IsValid() {
+		b.appendArg(stmt, nil)
+		return nil
+	}
+
+	t := v.Type()
+	if builder, ok := b.builder.LookupBuilder(t); ok {
+		if err := builder(stmt, x.Value); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	encoder, err := b.registry.LookupEncoder(v)
+	if err != nil {
+		return err
+	}
+	vv, err := encoder(nil, v)
+	if err != nil {
+		return err
+	}
+	switch vi := vv.(type) {
+	case string:
+		vv = escapeWildCard(vi)
+	case []byte:
+		vv = escapeWildCard(string(vi))
+	}
+	b.appendArg(stmt, vv)
+	return nil
+}
+
+// BuildField : postgres doesn't have `FIELD` function, use `ARRAY_POSITION` instead
+func (b *postgresBuilder) BuildField(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Field)
+	stmt.WriteString("ARRAY_POSITION")
+	stmt.WriteByte('(')
+	stmt.WriteString("ARRAY[")
+	for i, v := range x.Values {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		if err := b.getValue(stmt, v); err != nil {
+			return err
+		}
+	}
+	stmt.WriteString("],")
+	stmt.WriteString(b.Quote(x.Name))
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildValue :
+func (b *postgresBuilder) BuildValue(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	x := it.(primitive.Value)
+	v := reflext.ValueOf(x.Raw)
+	if !v.IsValid() {
+		b.appendArg(stmt, nil)
+		return
+	}
+
+	encoder, err := b.registry.LookupEncoder(v)
+	if err != nil {
+		return err
+	}
+	vv, err := encoder(nil, v)
+	if err != nil {
+		return err
+	}
+	convertSpatial(stmt, b.PostgresUtil, vv)
+	return nil
+}
+
+// BuildColumn :
+func (b *postgresBuilder) BuildColumn(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Column)
+	if x.Table != "" {
+		stmt.WriteString(b.Quote(x.Table))
+		stmt.WriteByte('.')
+	}
+	stmt.WriteString(b.Quote(x.Name))
+	return nil
+}
+
+// BuildJSONColumn :
+func (b *postgresBuilder) BuildJSONColumn(stmt sqlstmt.Stmt, it interface{}) error {
+	/*
+		Expected columns ( JSON_EXTRACT )
+		Column : Address
+		Nested : [ State, City ]
+		UnquoteResult : false
+
+		Result
+		"Address"#>'{State,City}'
+
+		--------------------------------------------
+
+		Expected columns ( JSON_EXTRACT(JSON_UNQUOTE) )
+		Column : Address
+		Nested : [ State, City ]
+		UnquoteResult : true
+
+		Result
+		"Address"#>>'{State,City}'
+	*/
+	x := it.(primitive.JSONColumn)
+	paths := make([]string, 0, len(x.Nested))
+	for _, n := range x.Nested {
+		n = strings.TrimPrefix(strings.TrimPrefix(n, "$"), ".")
+		if n == "" {
+			continue
+		}
+		paths = append(paths, strings.Split(n, ".")...)
+	}
+	operator := "#>"
+	if x.UnquoteResult {
+		operator += ">"
+	}
+	stmt.WriteString(b.Quote(x.Column) + operator + b.Wrap("{"+strings.Join(paths, ",")+"}"))
+	return nil
+}
+
+// BuildNil :
+func (b *postgresBuilder) BuildNil(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Nil)
+	if err := b.builder.BuildStatement(stmt, x.Field); err != nil {
+		return err
+	}
+	if x.IsNot {
+		stmt.WriteString(" IS NULL")
+	} else {
+		stmt.WriteString(" IS NOT NULL")
+	}
+	return nil
+}
+
+// BuildRaw :
+func (b *postgresBuilder) BuildRaw(stmt sqlstmt.Stmt, it interface{}) error {
+	x, ok := it.(primitive.Raw)
+	if ok {
+		stmt.WriteString(x.Value)
+	}
+	return nil
+}
+
+// BuildAs :
+func (b *postgresBuilder) BuildAs(stmt sqlstmt.Stmt, it interface{}) error {
+	stmt.WriteByte('(')
+	x := it.(primitive.As)
+	if err := b.getValue(stmt, x.Field); err != nil {
+		return err
+	}
+	stmt.WriteByte(')')
+	stmt.WriteString(" AS ")
+	stmt.WriteString(b.Quote(x.Name))
+	return nil
+}
+
+// BuildAggregate :
+func (b *postgresBuilder) BuildAggregate(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Aggregate)
+	switch x.By {
+	case primitive.Sum:
+		stmt.WriteString("COALESCE(SUM(")
+		if err := b.getValue(stmt, x.Field); err != nil {
+			return err
+		}
+		stmt.WriteString("),0)")
+		return nil
+	case primitive.Average:
+		stmt.WriteString("AVG")
+	case primitive.Count:
+		stmt.WriteString("COUNT")
+	case primitive.Max:
+		stmt.WriteString("MAX")
+	case primitive.Min:
+		stmt.WriteString("MIN")
+	}
+	stmt.WriteByte('(')
+	if err := b.getValue(stmt, x.Field); err != nil {
+		return err
+	}
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildOperator :
+func (b *postgresBuilder) BuildOperator(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Operator)
+	stmt.WriteByte(' ')
+	stmt.WriteString(operatorMap[x])
+	stmt.WriteByte(' ')
+	return nil
+}
+
+// BuildClause :
+func (b *postgresBuilder) BuildClause(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.C)
+	if err := b.builder.BuildStatement(stmt, x.Field); err != nil {
+		return err
+	}
+
+	stmt.WriteString(" " + operatorMap[x.Operator] + " ")
+	switch x.Operator {
+	case primitive.IsNull, primitive.NotNull:
+		return nil
+	}
+
+	if err := b.getValue(stmt, x.Value); err != nil {
+		return err
+	}
+	return nil
+}
+
+// BuildSort :
+func (b *postgresBuilder) BuildSort(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Sort)
+	if err := b.builder.BuildStatement(stmt, x.Field); err != nil {
+		return err
+	}
+	if x.Order == primitive.Descending {
+		stmt.WriteByte(' ')
+		stmt.WriteString("DESC")
+	}
+	return nil
+}
+
+// BuildKeyValue :
+func (b *postgresBuilder) BuildKeyValue(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	x := it.(primitive.KV)
+	stmt.WriteString(b.Quote(string(x.Field)))
+	stmt.WriteString(" = ")
+	return b.getValue(stmt, x.Value)
+}
+
+// BuildMath :
+func (b *postgresBuilder) BuildMath(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	x := it.(primitive.Math)
+	stmt.WriteString(b.Quote(string(x.Field)) + " ")
+	if x.Mode == primitive.Add {
+		stmt.WriteByte('+')
+	} else {
+		stmt.WriteByte('-')
+	}
+	stmt.WriteString(" " + strconv.Itoa(x.Value))
+	return
+}
+
+// BuildInserted :
+func (b *postgresBuilder) BuildInserted(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Inserted)
+	stmt.WriteString("EXCLUDED." + b.Quote(x.Field))
+	return nil
+}
+
+// BuildCase :
+func (b *postgresBuilder) BuildCase(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(*primitive.Case)
+	stmt.WriteByte('(')
+	stmt.WriteString("CASE")
+	for _, w := range x.WhenClauses {
+		stmt.WriteString(" WHEN ")
+		if err := b.builder.BuildStatement(stmt, w[0]); err != nil {
+			return err
+		}
+		stmt.WriteString(" THEN ")
+		if err := b.getValue(stmt, w[1]); err != nil {
+			return err
+		}
+	}
+	stmt.WriteString(" ELSE ")
+	if x.ElseClause != nil {
+		if err := b.getValue(stmt, x.ElseClause); err != nil {
+			return err
+		}
+	}
+	stmt.WriteString(" END")
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildJoin :
+func (b *postgresBuilder) BuildJoin(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Join)
+	stmt.WriteString(x.Type.String() + " ")
+	if err := b.builder.BuildStatement(stmt, x.Table); err != nil {
+		return err
+	}
+	if len(x.Using) > 0 {
+		stmt.WriteString(" USING (")
+		for i, col := range x.Using {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			stmt.WriteString(b.Quote(col))
+		}
+		stmt.WriteByte(')')
+		return nil
+	}
+	if len(x.On.Values) > 0 {
+		stmt.WriteString(" ON ")
+		if err := b.builder.BuildStatement(stmt, x.On); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BuildCTE :
+func (b *postgresBuilder) BuildCTE(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.CTE)
+	stmt.WriteString(b.Quote(x.Name))
+	if len(x.Columns) > 0 {
+		stmt.WriteString(" (")
+		for i, col := range x.Columns {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			stmt.WriteString(b.Quote(col))
+		}
+		stmt.WriteByte(')')
+	}
+	stmt.WriteString(" AS (")
+	if err := b.builder.BuildStatement(stmt, x.Anchor); err != nil {
+		return err
+	}
+	if x.Recursion != nil {
+		stmt.WriteString(" UNION ALL ")
+		if err := b.builder.BuildStatement(stmt, x.Recursion); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildWindowFunc :
+func (b *postgresBuilder) BuildWindowFunc(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.WindowFunc)
+	stmt.WriteString(x.Type.String())
+	stmt.WriteByte('(')
+	for i, arg := range x.Args {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		if err := b.builder.BuildStatement(stmt, arg); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildWindow :
+func (b *postgresBuilder) BuildWindow(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(*primitive.Window)
+	stmt.WriteByte('(')
+	if x.Base != "" {
+		stmt.WriteString(b.Quote(x.Base))
+	}
+	if len(x.Partitions) > 0 {
+		if x.Base != "" {
+			stmt.WriteByte(' ')
+		}
+		stmt.WriteString("PARTITION BY ")
+		for i, field := range x.Partitions {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			if err := b.builder.BuildStatement(stmt, field); err != nil {
+				return err
+			}
+		}
+	}
+	if len(x.Sorts) > 0 {
+		if x.Base != "" || len(x.Partitions) > 0 {
+			stmt.WriteByte(' ')
+		}
+		stmt.WriteString("ORDER BY ")
+		for i, sort := range x.Sorts {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			if err := b.builder.BuildStatement(stmt, sort); err != nil {
+				return err
+			}
+		}
+	}
+	if x.Frame != nil {
+		if x.Base != "" || len(x.Partitions) > 0 || len(x.Sorts) > 0 {
+			stmt.WriteByte(' ')
+		}
+		stmt.WriteString(x.Frame.Unit.String() + " BETWEEN ")
+		if err := b.builder.BuildStatement(stmt, x.Frame.Start); err != nil {
+			return err
+		}
+		stmt.WriteString(" AND ")
+		if err := b.builder.BuildStatement(stmt, x.Frame.End); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildOver :
+func (b *postgresBuilder) BuildOver(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Over)
+	// `COALESCE` is not allowed in between `SUM` and `OVER`
+	if agg, ok := x.Field.(primitive.Aggregate); ok && agg.By == primitive.Sum {
+		stmt.WriteString("SUM(")
+		if err := b.getValue(stmt, agg.Field); err != nil {
+			return err
+		}
+		stmt.WriteByte(')')
+	} else if err := b.builder.BuildStatement(stmt, x.Field); err != nil {
+		return err
+	}
+	stmt.WriteString(" OVER ")
+	switch vi := x.Window.(type) {
+	case string:
+		stmt.WriteString(b.Quote(vi))
+	case *primitive.Window:
+		if err := b.BuildWindow(stmt, vi); err != nil {
+			return err
+		}
+	default:
+		stmt.WriteString("()")
+	}
+	return nil
+}
+
+// BuildSpatialFunc :
+func (b *postgresBuilder) BuildSpatialFunc(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	x := it.(spatial.Func)
+	stmt.WriteString(x.Type.String())
+	stmt.WriteByte('(')
+	for i, arg := range x.Args {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		if err := b.builder.BuildStatement(stmt, arg); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(')')
+	return
+}
+
+// BuildGroup :
+func (b *postgresBuilder) BuildGroup(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	x := it.(primitive.Group)
+	for len(x.Values) > 0 {
+		if err := b.getValue(stmt, x.Values[0]); err != nil {
+			return err
+		}
+		x.Values = x.Values[1:]
+	}
+	return
+}
+
+// BuildRange :
+func (b *postgresBuilder) BuildRange(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	x := it.(primitive.R)
+	v := reflext.ValueOf(x.From)
+	encoder, err := b.registry.LookupEncoder(v)
+	if err != nil {
+		return err
+	}
+	arg, err := encoder(nil, v)
+	if err != nil {
+		return err
+	}
+	b.appendArg(stmt, arg)
+	stmt.WriteString(" AND ")
+
+	v = reflext.ValueOf(x.To)
+	encoder, err = b.registry.LookupEncoder(v)
+	if err != nil {
+		return err
+	}
+	arg, err = encoder(nil, v)
+	if err != nil {
+		return err
+	}
+	b.appendArg(stmt, arg)
+	return
+}
+
+// BuildEncoding : postgres doesn't support charset introducer, only collation is apply
+func (b *postgresBuilder) BuildEncoding(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	x := it.(primitive.Encoding)
+	err = b.builder.BuildStatement(stmt, x.Column)
+	if err != nil {
+		return
+	}
+	stmt.WriteString(" COLLATE " + b.Quote(x.Collate))
+	return
+}
+
+// BuildTypeSafe :
+func (b *postgresBuilder) BuildTypeSafe(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	ts := it.(primitive.TypeSafe)
+	switch ts.Type {
+	case reflect.String:
+		stmt.WriteString(b.Wrap(ts.Value.(string)))
+	case reflect.Bool:
+		v := ts.Value.(bool)
+		if v {
+			stmt.WriteString("TRUE")
+		} else {
+			stmt.WriteString("FALSE")
+		}
+	case reflect.Int:
+		stmt.WriteString(strconv.FormatInt(int64(ts.Value.(int)), 10))
+	case reflect.Int8:
+		stmt.WriteString(strconv.FormatInt(int64(ts.Value.(int8)), 10))
+	case reflect.Int16:
+		stmt.WriteString(strconv.FormatInt(int64(ts.Value.(int16)), 10))
+	case reflect.Int32:
+		stmt.WriteString(strconv.FormatInt(int64(ts.Value.(int32)), 10))
+	case reflect.Int64:
+		stmt.WriteString(strconv.FormatInt(ts.Value.(int64), 10))
+	case reflect.Uint:
+		stmt.WriteString(strconv.FormatUint(uint64(ts.Value.(uint)), 10))
+	case reflect.Uint8:
+		stmt.WriteString(strconv.FormatUint(uint64(ts.Value.(uint8)), 10))
+	case reflect.Uint16:
+		stmt.WriteString(strconv.FormatUint(uint64(ts.Value.(uint16)), 10))
+	case reflect.Uint32:
+		stmt.WriteString(strconv.FormatUint(uint64(ts.Value.(uint32)), 10))
+	case reflect.Uint64:
+		stmt.WriteString(strconv.FormatUint(ts.Value.(uint64), 10))
+	case reflect.Float32:
+		stmt.WriteString(strconv.FormatFloat(float64(ts.Value.(float32)), 'e', -1, 64))
+	case reflect.Float64:
+		stmt.WriteString(strconv.FormatFloat(ts.Value.(float64), 'e', -1, 64))
+	}
+	return
+}
+
+// BuildSelectStmt :
+func (b *postgresBuilder) BuildSelectStmt(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(*sql.SelectStmt)
+	if err := b.appendWith(stmt, x.CTEs); err != nil {
+		return err
+	}
+	stmt.WriteString("SELECT ")
+	if x.DistinctOn {
+		stmt.WriteString("DISTINCT ")
+	}
+	if err := b.appendSelect(stmt, x.Projections); err != nil {
+		return err
+	}
+	stmt.WriteString(" FROM ")
+	if err := b.appendTable(stmt, x.Tables); err != nil {
+		return err
+	}
+	if err := b.appendJoins(stmt, x.Joins); err != nil {
+		return err
+	}
+	if err := b.appendWhere(stmt, x.Conditions.Values); err != nil {
+		return err
+	}
+	if err := b.appendGroupBy(stmt, x.Groups); err != nil {
+		return err
+	}
+	if err := b.appendWindow(stmt, x.Windows); err != nil {
+		return err
+	}
+	if err := b.appendOrderBy(stmt, x.Sorts); err != nil {
+		return err
+	}
+	b.appendLimitNOffset(stmt, x.Max, x.Skip)
+	return nil
+}
+
+// BuildUpdateStmt :
+func (b *postgresBuilder) BuildUpdateStmt(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(*sql.UpdateStmt)
+	if err := b.appendWith(stmt, x.CTEs); err != nil {
+		return err
+	}
+	table := b.TableName(x.Database, x.Table)
+	stmt.WriteString("UPDATE " + table + ` `)
+	if err := b.appendSet(stmt, x.Values); err != nil {
+		return err
+	}
+	return b.appendLimitedWhere(stmt, table, x.Conditions.Values, x.Sorts, x.Max)
+}
+
+// BuildFindActions :
+func (b *postgresBuilder) BuildFindActions(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(*actions.FindActions)
+	x.Table = strings.TrimSpace(x.Table)
+	if x.Table == "" {
+		return errors.New("postgres: empty table name")
+	}
+	if err := b.appendWith(stmt, x.CTEs); err != nil {
+		return err
+	}
+	stmt.WriteString("SELECT ")
+	if x.DistinctOn {
+		stmt.WriteString("DISTINCT ")
+	}
+	if err := b.appendSelect(stmt, x.Projections); err != nil {
+		return err
+	}
+	stmt.WriteString(" FROM ")
+	if hasCTE(x.CTEs, x.Table) {
+		// common table expression can't be qualified with database name
+		stmt.WriteString(b.Quote(x.Table))
+	} else {
+		stmt.WriteString(b.TableName(x.Database, x.Table))
+	}
+	if err := b.appendJoins(stmt, x.Joins); err != nil {
+		return err
+	}
+	if err := b.appendWhere(stmt, x.Conditions.Values); err != nil {
+		return err
+	}
+	if err := b.appendGroupBy(stmt, x.GroupBys); err != nil {
+		return err
+	}
+	if err := b.appendOrderBy(stmt, x.Sorts); err != nil {
+		return err
+	}
+	b.appendLimitNOffset(stmt, x.Count, x.Skip)
+
+	return nil
+}
+
+// BuildUpdateActions :
+func (b *postgresBuilder) BuildUpdateActions(stmt sqlstmt.Stmt, it interface{}) error {
+	x, ok := it.(*actions.UpdateActions)
+	if !ok {
+		return errors.New("data type not match")
+	}
+	table := b.TableName(x.Database, x.Table)
+	stmt.WriteString("UPDATE " + table + ` `)
+	if err := b.appendSet(stmt, x.Values); err != nil {
+		return err
+	}
+	return b.appendLimitedWhere(stmt, table, x.Conditions, x.Sorts, x.Record)
+}
+
+// BuildDeleteActions :
+func (b *postgresBuilder) BuildDeleteActions(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(*actions.DeleteActions)
+	table := b.TableName(x.Database, x.Table)
+	stmt.WriteString("DELETE FROM " + table)
+	return b.appendLimitedWhere(stmt, table, x.Conditions, x.Sorts, x.Record)
+}
+
+func (b *postgresBuilder) getValue(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	v := reflext.ValueOf(it)
+	if !v.IsValid() {
+		b.appendArg(stmt, nil)
+		return
+	}
+
+	t := v.Type()
+	if builder, ok := b.builder.LookupBuilder(t); ok {
+		if err := builder(stmt, it); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	encoder, err := b.registry.LookupEncoder(v)
+	if err != nil {
+		return err
+	}
+	vv, err := encoder(nil, v)
+	if err != nil {
+		return err
+	}
+	convertSpatial(stmt, b.PostgresUtil, vv)
+	return
+}
+
+// appendArg : postgres placeholder is positional, so the position is depends on the number of arguments
+func (b *postgresBuilder) appendArg(stmt sqlstmt.Stmt, arg interface{}) {
+	stmt.WriteString(b.Var(len(stmt.Args()) + 1))
+	stmt.AppendArgs(normalizeArg(arg))
+}
+
+func (b *postgresBuilder) appendSelect(stmt sqlstmt.Stmt, pjs []interface{}) error {
+	if len(pjs) > 0 {
+		length := len(pjs)
+		for i := 0; i < length; i++ {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			if err := b.builder.BuildStatement(stmt, pjs[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	stmt.WriteString("*")
+	return nil
+}
+
+func (b *postgresBuilder) appendTable(stmt sqlstmt.Stmt, fields []interface{}) error {
+	length := len(fields)
+	if length > 0 {
+		for i := 0; i < length; i++ {
+			if i > 0 {
+				stmt.WriteByte(' ')
+			}
+			if err := b.builder.BuildStatement(stmt, fields[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (b *postgresBuilder) appendWith(stmt sqlstmt.Stmt, ctes []primitive.CTE) error {
+	if len(ctes) == 0 {
+		return nil
+	}
+	stmt.WriteString("WITH ")
+	for _, cte := range ctes {
+		if cte.Recursive {
+			stmt.WriteString("RECURSIVE ")
+			break
+		}
+	}
+	for i, cte := range ctes {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		if err := b.builder.BuildStatement(stmt, cte); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(' ')
+	return nil
+}
+
+func hasCTE(ctes []primitive.CTE, name string) bool {
+	for _, cte := range ctes {
+		if cte.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *postgresBuilder) appendJoins(stmt sqlstmt.Stmt, joins []interface{}) error {
+	for _, j := range joins {
+		stmt.WriteByte(' ')
+		if err := b.builder.BuildStatement(stmt, j); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *postgresBuilder) appendWhere(stmt sqlstmt.Stmt, conds []interface{}) error {
+	length := len(conds)
+	if length > 0 {
+		stmt.WriteString(" WHERE ")
+		for i := 0; i < length; i++ {
+			if err := b.builder.BuildStatement(stmt, conds[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// appendLimitedWhere : postgres doesn't support `ORDER BY` and `LIMIT` on `UPDATE` and `DELETE`,
+// so we narrow down the affected rows using the physical row id (ctid) instead
+func (b *postgresBuilder) appendLimitedWhere(stmt sqlstmt.Stmt, table string, conds []interface{}, sorts []interface{}, limit uint) error {
+	if limit < 1 {
+		return b.appendWhere(stmt, conds)
+	}
+	stmt.WriteString(" WHERE ctid IN (SELECT ctid FROM " + table)
+	if err := b.appendWhere(stmt, conds); err != nil {
+		return err
+	}
+	if err := b.appendOrderBy(stmt, sorts); err != nil {
+		return err
+	}
+	b.appendLimitNOffset(stmt, limit, 0)
+	stmt.WriteByte(')')
+	return nil
+}
+
+func (b *postgresBuilder) appendGroupBy(stmt sqlstmt.Stmt, fields []interface{}) error {
+	length := len(fields)
+	if length > 0 {
+		stmt.WriteString(" GROUP BY ")
+		for i := 0; i < length; i++ {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			if err := b.builder.BuildStatement(stmt, fields[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (b *postgresBuilder) appendWindow(stmt sqlstmt.Stmt, windows []primitive.NamedWindow) error {
+	length := len(windows)
+	if length < 1 {
+		return nil
+	}
+	stmt.WriteString(" WINDOW ")
+	for i := 0; i < length; i++ {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteString(b.Quote(windows[i].Name) + " AS ")
+		if err := b.BuildWindow(stmt, windows[i].Window); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *postgresBuilder) appendOrderBy(stmt sqlstmt.Stmt, sorts []interface{}) error {
+	length := len(sorts)
+	if length < 1 {
+		return nil
+	}
+	stmt.WriteString(" ORDER BY ")
+	for i := 0; i < length; i++ {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		if err := b.builder.BuildStatement(stmt, sorts[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *postgresBuilder) appendLimitNOffset(stmt sqlstmt.Stmt, limit, offset uint) {
+	if limit > 0 {
+		stmt.WriteString(" LIMIT " + strconv.FormatUint(uint64(limit), 10))
+	}
+	if offset > 0 {
+		stmt.WriteString(" OFFSET " + strconv.FormatUint(uint64(offset), 10))
+	}
+}
+
+func (b *postgresBuilder) appendSet(stmt sqlstmt.Stmt, values []primitive.KV) error {
+	length := len(values)
+	if length > 0 {
+		stmt.WriteString("SET ")
+		for i := 0; i < length; i++ {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			if err := b.builder.BuildStatement(stmt, values[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func convertSpatial(stmt sqlstmt.Stmt, utl sqlutil.PostgresUtil, val interface{}) {
+	switch vi := val.(type) {
+	case spatial.Geometry:
+		stmt.WriteString("ST_GeomFromText(")
+		stmt.WriteString(utl.Var(len(stmt.Args()) + 1))
+		if vi.SRID > 0 {
+			stmt.WriteString(fmt.Sprintf(",%d", vi.SRID))
+		}
+		stmt.WriteByte(')')
+		stmt.AppendArgs(vi.WKT)
+
+	default:
+		stmt.WriteString(utl.Var(len(stmt.Args()) + 1))
+		stmt.AppendArgs(normalizeArg(val))
+	}
+}
+
+// normalizeArg : the codec will encode json as bytes, but postgres driver will treat bytes as `BYTEA`,
+// so we pass it as string in order to store it as `JSONB`
+func normalizeArg(arg interface{}) interface{} {
+	switch vi := arg.(type) {
+	case json.RawMessage:
+		return string(vi)
+	default:
+		return arg
+	}
+}
+
+func escapeWildCard(n string) string {
+	length := len(n) - 1
+	if length < 1 {
+		return n
+	}
+	blr := new(strings.Builder)
+	for i := 0; i < length; i++ {
+		switch n[i] {
+		case '%':
+			blr.WriteString(`\%`)
+		case '_':
+			blr.WriteString(`\_`)
+		case '\\':
+			blr.WriteString(`\\`)
+		default:
+			blr.WriteByte(n[i])
+		}
+	}
+	blr.WriteByte(n[length])
+	return blr.String()
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/columns"
+)
+
+// GetColumns :
+func (pg *Postgres) GetColumns(stmt sqlstmt.Stmt, dbName, table string) {
+	stmt.WriteString(`SELECT ordinal_position, column_name,
+	CASE WHEN character_maximum_length IS NOT NULL THEN data_type || '(' || character_maximum_length || ')' ELSE data_type END,
+	column_default, is_nullable, data_type, character_set_name, collation_name,
+	COALESCE(col_description((quote_ident(table_schema) || '.' || quote_ident(table_name))::regclass, ordinal_position), ''),
+	CASE WHEN is_identity = 'YES' THEN 'IDENTITY' ELSE '' END
+	FROM information_schema.columns WHERE table_schema = $1 AND table_name = $2 ORDER BY ordinal_position;`)
+	stmt.AppendArgs(dbName, table)
+}
+
+// RenameColumn :
+func (pg *Postgres) RenameColumn(stmt sqlstmt.Stmt, db, table, oldColName, newColName string) {
+	stmt.WriteString("ALTER TABLE " + pg.TableName(db, table))
+	stmt.WriteString(" RENAME COLUMN " + pg.Quote(oldColName) + " TO " + pg.Quote(newColName))
+	stmt.WriteByte(';')
+}
+
+// DropColumn :
+func (pg *Postgres) DropColumn(stmt sqlstmt.Stmt, db, table, column string) {
+	stmt.WriteString("ALTER TABLE " + pg.TableName(db, table))
+	stmt.WriteString(" DROP COLUMN " + pg.Quote(column))
+	stmt.WriteByte(';')
+}
+
+// ModifyColumn : the column definition is the one returned by `GetColumns`, postgres doesn't support the column position
+func (pg *Postgres) ModifyColumn(stmt sqlstmt.Stmt, db, table string, col columns.Column, after string) {
+	name := pg.Quote(col.Name)
+	stmt.WriteString("ALTER TABLE " + pg.TableName(db, table))
+	stmt.WriteString(" ALTER COLUMN " + name + " TYPE " + col.Type + " USING " + name + "::" + col.Type)
+	if col.Extra == "IDENTITY" {
+		stmt.WriteByte(';')
+		return
+	}
+	stmt.WriteString(",ALTER COLUMN " + name)
+	if col.Nullable {
+		stmt.WriteString(" DROP NOT NULL")
+	} else {
+		stmt.WriteString(" SET NOT NULL")
+	}
+	// the default value returned by postgres is an expression already, eg. 'abc'::character varying
+	stmt.WriteString(",ALTER COLUMN " + name)
+	if col.DefaultValue != nil {
+		stmt.WriteString(" SET DEFAULT " + *col.DefaultValue)
+	} else {
+		stmt.WriteString(" DROP DEFAULT")
+	}
+	stmt.WriteByte(';')
+}
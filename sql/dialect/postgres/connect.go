@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"net/url"
+
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+)
+
+// Connect :
+func (pg Postgres) Connect(opt *options.ConnectOptions) (connStr string) {
+	if opt.RawConnStr() != "" {
+		connStr = opt.RawConnStr()
+		return
+	}
+
+	if opt.Username == "" {
+		panic("missing username for db connection")
+	}
+
+	u := new(url.URL)
+	u.Scheme = "postgres"
+	u.User = url.UserPassword(opt.Username, opt.Password)
+	u.Path = "/"
+	query := url.Values{}
+	if opt.Socket != "" {
+		// unix socket is passing using `host` query string
+		query.Set("host", opt.Socket)
+	} else {
+		u.Host = opt.Host
+		if opt.Port != "" {
+			u.Host += ":" + opt.Port
+		}
+	}
+	if opt.Charset != "" {
+		query.Set("client_encoding", string(opt.Charset))
+	}
+	u.RawQuery = query.Encode()
+	connStr = u.String()
+	return
+}
@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+)
+
+// UseDatabase : postgres doesn't allow to switch database on the same connection, so we map database to schema instead
+func (pg Postgres) UseDatabase(stmt sqlstmt.Stmt, db string) {
+	stmt.WriteString("SET search_path TO " + pg.Quote(db) + ";")
+}
+
+// CreateDatabase :
+func (pg Postgres) CreateDatabase(stmt sqlstmt.Stmt, db string, checkExists bool) {
+	stmt.WriteString("CREATE SCHEMA")
+	if checkExists {
+		stmt.WriteString(" IF NOT EXISTS")
+	}
+	stmt.WriteByte(' ')
+	stmt.WriteString(pg.Quote(db) + ";")
+}
+
+// DropDatabase :
+func (pg Postgres) DropDatabase(stmt sqlstmt.Stmt, db string, checkExists bool) {
+	stmt.WriteString("DROP SCHEMA")
+	if checkExists {
+		stmt.WriteString(" IF EXISTS")
+	}
+	stmt.WriteByte(' ')
+	stmt.WriteString(pg.Quote(db) + " CASCADE;")
+}
+
+// GetDatabases :
+func (pg Postgres) GetDatabases(stmt sqlstmt.Stmt) {
+	stmt.WriteString("SELECT schema_name FROM information_schema.schemata;")
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"testing"
+
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseDatabase(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+	pg.UseDatabase(stmt, "db")
+	require.Equal(t, `SET search_path TO "db";`, stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
+
+func TestCreateDatabase(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	{
+		pg.CreateDatabase(stmt, "db", false)
+		require.Equal(t, `CREATE SCHEMA "db";`, stmt.String())
+		require.ElementsMatch(t, []interface{}{}, stmt.Args())
+	}
+
+	stmt.Reset()
+
+	{
+		pg.CreateDatabase(stmt, "db", true)
+		require.Equal(t, `CREATE SCHEMA IF NOT EXISTS "db";`, stmt.String())
+		require.ElementsMatch(t, []interface{}{}, stmt.Args())
+	}
+}
+
+func TestDropDatabase(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	{
+		pg.DropDatabase(stmt, "db", false)
+		require.Equal(t, `DROP SCHEMA "db" CASCADE;`, stmt.String())
+		require.ElementsMatch(t, []interface{}{}, stmt.Args())
+	}
+
+	stmt.Reset()
+
+	{
+		pg.DropDatabase(stmt, "db", true)
+		require.Equal(t, `DROP SCHEMA IF EXISTS "db" CASCADE;`, stmt.String())
+		require.ElementsMatch(t, []interface{}{}, stmt.Args())
+	}
+}
@@ -0,0 +1,29 @@
+package postgres
+
+import "errors"
+
+// sqlstate of postgres which the transaction can be retried
+const (
+	errSerializationFailure = "40001"
+	errDeadlockDetected     = "40P01"
+	errLockNotAvailable     = "55P03"
+)
+
+// sqlStater : implemented by the error of postgres drivers, eg. `pgconn.PgError` and `pq.Error`
+type sqlStater interface {
+	SQLState() string
+}
+
+// IsRetryableError : the transaction is aborted by serialization failure, deadlock or lock timeout, so it's safe to be retried
+func (pg Postgres) IsRetryableError(err error) bool {
+	var se sqlStater
+	if !errors.As(err, &se) {
+		return false
+	}
+	switch se.SQLState() {
+	case errSerializationFailure, errDeadlockDetected, errLockNotAvailable:
+		return true
+	default:
+		return false
+	}
+}
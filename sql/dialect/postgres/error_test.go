@@ -0,0 +1,24 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type pgError string
+
+func (e pgError) Error() string    { return "pg: " + string(e) }
+func (e pgError) SQLState() string { return string(e) }
+
+func TestIsRetryableError(t *testing.T) {
+	pg := New()
+	require.True(t, pg.IsRetryableError(pgError("40001")))
+	require.True(t, pg.IsRetryableError(fmt.Errorf("update: %w", pgError("40P01"))))
+	require.True(t, pg.IsRetryableError(pgError("55P03")))
+	require.False(t, pg.IsRetryableError(pgError("23505")))
+	require.False(t, pg.IsRetryableError(errors.New("40001")))
+	require.False(t, pg.IsRetryableError(nil))
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"strings"
+
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/indexes"
+)
+
+// GetForeignKeys :
+func (pg Postgres) GetForeignKeys(stmt sqlstmt.Stmt, db, table string) {
+	stmt.WriteString(`SELECT tc.constraint_name, kcu.column_name, ccu.table_name, ccu.column_name, rc.delete_rule, rc.update_rule
+	FROM information_schema.table_constraints tc
+	JOIN information_schema.key_column_usage kcu ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name
+	JOIN information_schema.referential_constraints rc ON rc.constraint_schema = tc.constraint_schema AND rc.constraint_name = tc.constraint_name
+	JOIN information_schema.key_column_usage ccu ON ccu.constraint_schema = rc.unique_constraint_schema AND ccu.constraint_name = rc.unique_constraint_name AND ccu.ordinal_position = kcu.position_in_unique_constraint
+	WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = $1 AND tc.table_name = $2 ORDER BY tc.constraint_name, kcu.ordinal_position;`)
+	stmt.AppendArgs(db, table)
+}
+
+// AddForeignKeys :
+func (pg Postgres) AddForeignKeys(stmt sqlstmt.Stmt, db, table string, fks []indexes.ForeignKey) {
+	if len(fks) == 0 {
+		return
+	}
+	stmt.WriteString("ALTER TABLE " + pg.TableName(db, table) + " ")
+	for i, fk := range fks {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteString("ADD ")
+		pg.buildForeignKey(stmt, db, table, fk)
+	}
+	stmt.WriteByte(';')
+}
+
+// DropForeignKeys :
+func (pg Postgres) DropForeignKeys(stmt sqlstmt.Stmt, db, table string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	stmt.WriteString("ALTER TABLE " + pg.TableName(db, table) + " ")
+	for i, name := range names {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteString("DROP CONSTRAINT " + pg.Quote(name))
+	}
+	stmt.WriteByte(';')
+}
+
+func (pg Postgres) buildForeignKey(stmt sqlstmt.Stmt, db, table string, fk indexes.ForeignKey) {
+	stmt.WriteString("CONSTRAINT " + pg.Quote(fk.GetName(table)))
+	stmt.WriteString(" FOREIGN KEY (" + pg.quoteAll(fk.Columns) + ")")
+	stmt.WriteString(" REFERENCES " + pg.TableName(db, fk.RefTable) + " (" + pg.quoteAll(fk.RefColumns) + ")")
+	if fk.OnDelete != "" {
+		stmt.WriteString(" ON DELETE " + fk.OnDelete)
+	}
+	if fk.OnUpdate != "" {
+		stmt.WriteString(" ON UPDATE " + fk.OnUpdate)
+	}
+}
+
+func (pg Postgres) quoteAll(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = pg.Quote(n)
+	}
+	return strings.Join(quoted, ",")
+}
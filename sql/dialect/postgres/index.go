@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"strings"
+
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/indexes"
+)
+
+// HasIndexByName :
+func (pg Postgres) HasIndexByName(stmt sqlstmt.Stmt, dbName, table, indexName string) {
+	stmt.WriteString(`SELECT COUNT(1) FROM pg_indexes WHERE schemaname = $1 AND tablename = $2 AND indexname = $3;`)
+	stmt.AppendArgs(dbName, table, indexName)
+}
+
+// HasIndex :
+func (pg Postgres) HasIndex(stmt sqlstmt.Stmt, dbName, table string, idx indexes.Index) {
+	unique := false
+	switch idx.Type {
+	case indexes.Unique, indexes.Primary:
+		unique = true
+	}
+	args := []interface{}{dbName, table, pg.getIndexMethod(idx.Type), unique}
+	stmt.WriteString("SELECT COUNT(1) FROM (")
+	stmt.WriteString("SELECT i.relname, COUNT(*) AS c FROM pg_index ix ")
+	stmt.WriteString("JOIN pg_class t ON t.oid = ix.indrelid ")
+	stmt.WriteString("JOIN pg_class i ON i.oid = ix.indexrelid ")
+	stmt.WriteString("JOIN pg_namespace n ON n.oid = t.relnamespace ")
+	stmt.WriteString("JOIN pg_am am ON am.oid = i.relam ")
+	stmt.WriteString("JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = ANY(ix.indkey) ")
+	stmt.WriteString("WHERE n.nspname = $1 ")
+	stmt.WriteString("AND t.relname = $2 ")
+	stmt.WriteString("AND am.amname = $3 ")
+	stmt.WriteString("AND ix.indisunique = $4 ")
+	stmt.WriteString("AND a.attname IN ")
+	stmt.WriteByte('(')
+	for i, col := range idx.Columns {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		args = append(args, col.Name)
+		stmt.WriteString(pg.Var(len(args)))
+	}
+	stmt.WriteByte(')')
+	stmt.WriteString(" GROUP BY i.relname")
+	args = append(args, int64(len(idx.Columns)))
+	stmt.WriteString(") AS temp WHERE temp.c = " + pg.Var(len(args)))
+	stmt.WriteByte(';')
+	stmt.AppendArgs(args...)
+}
+
+// GetIndexes :
+func (pg Postgres) GetIndexes(stmt sqlstmt.Stmt, dbName, table string) {
+	stmt.WriteString(`SELECT DISTINCT i.relname, UPPER(am.amname), NOT ix.indisunique FROM pg_index ix `)
+	stmt.WriteString(`JOIN pg_class t ON t.oid = ix.indrelid `)
+	stmt.WriteString(`JOIN pg_class i ON i.oid = ix.indexrelid `)
+	stmt.WriteString(`JOIN pg_namespace n ON n.oid = t.relnamespace `)
+	stmt.WriteString(`JOIN pg_am am ON am.oid = i.relam `)
+	stmt.WriteString(`WHERE n.nspname = $1 AND t.relname = $2;`)
+	stmt.AppendArgs(dbName, table)
+}
+
+// CreateIndexes : postgres doesn't support adding index using `ALTER TABLE`, so we will create it one by one
+func (pg Postgres) CreateIndexes(stmt sqlstmt.Stmt, db, table string, idxs []indexes.Index, supportDesc bool) {
+	for _, idx := range idxs {
+		name := idx.GetName()
+		stmt.WriteString("CREATE ")
+		if idx.Type == indexes.Unique || idx.Type == indexes.Primary {
+			stmt.WriteString("UNIQUE ")
+		}
+		stmt.WriteString("INDEX " + pg.Quote(name) + " ON " + pg.TableName(db, table))
+		stmt.WriteString(" USING " + pg.getIndexMethod(idx.Type) + " ")
+
+		switch idx.Type {
+		case indexes.MultiValued:
+			stmt.WriteString("((" + idx.Cast + "))")
+		case indexes.FullText:
+			stmt.WriteString("(to_tsvector('simple', ")
+			for j, col := range idx.Columns {
+				if j > 0 {
+					stmt.WriteString(" || ' ' || ")
+				}
+				stmt.WriteString(pg.Quote(col.Name))
+			}
+			stmt.WriteString("))")
+		default:
+			stmt.WriteByte('(')
+			for j, col := range idx.Columns {
+				if j > 0 {
+					stmt.WriteByte(',')
+				}
+				stmt.WriteString(pg.Quote(col.Name))
+				if !supportDesc {
+					continue
+				}
+				if col.Direction == indexes.Descending {
+					stmt.WriteString(" DESC")
+				}
+			}
+			stmt.WriteByte(')')
+		}
+		stmt.WriteByte(';')
+
+		if idx.Comment != "" {
+			stmt.WriteString("COMMENT ON INDEX " + pg.TableName(db, name) + " IS " + pg.Wrap(idx.Comment) + ";")
+		}
+	}
+}
+
+// DropIndexes : nothing is written if there is no index to drop, eg. only the primary key is given
+func (pg Postgres) DropIndexes(stmt sqlstmt.Stmt, db, table string, idxs []string) {
+	names := make([]string, 0, len(idxs))
+	for _, idx := range idxs {
+		// primary key is a constraint, it cannot be drop using `DROP INDEX`
+		if idx == "PRIMARY" || idx == table+"_pkey" {
+			continue
+		}
+		names = append(names, pg.TableName(db, idx))
+	}
+	if len(names) == 0 {
+		return
+	}
+	stmt.WriteString("DROP INDEX " + strings.Join(names, ",") + ";")
+}
+
+func (pg Postgres) getIndexMethod(k indexes.Type) (method string) {
+	switch k {
+	case indexes.FullText, indexes.MultiValued:
+		method = "gin"
+	case indexes.Spatial:
+		method = "gist"
+	default:
+		method = "btree"
+	}
+	return
+}
+
+// DropUniqueIndex : the unique index is added as constraint by `AlterTable`
+func (pg Postgres) DropUniqueIndex(stmt sqlstmt.Stmt, db, table, name string) {
+	if name == "PRIMARY" {
+		name = table + "_pkey"
+	}
+	stmt.WriteString("ALTER TABLE " + pg.TableName(db, table))
+	stmt.WriteString(" DROP CONSTRAINT " + pg.Quote(name) + ";")
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/codec"
+	"github.com/RevenueMonster/sqlike/sql/expr"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertInto(t *testing.T) {
+	type counter struct {
+		ID      string `sqlike:",primary_key"`
+		Name    string
+		Counter int
+	}
+
+	var (
+		pg     = New()
+		fields = reflext.DefaultMapper.CodecByType(reflect.TypeOf(counter{})).Properties()
+		v      = reflect.ValueOf([]counter{{ID: "1", Name: "a", Counter: 2}})
+	)
+
+	{
+		stmt := sqlstmt.NewStatement(pg)
+		err := pg.InsertInto(stmt, "db", "t", "$Key", reflext.DefaultMapper, codec.DefaultRegistry, fields, v, options.Insert().SetMode(options.InsertOnDuplicate))
+		require.NoError(t, err)
+		require.Equal(t, `INSERT INTO "db"."t" ("ID","Name","Counter") VALUES ($1,$2,$3) ON CONFLICT ("ID") DO UPDATE SET "Name"=EXCLUDED."Name","Counter"=EXCLUDED."Counter";`, stmt.String())
+	}
+
+	{
+		type user struct {
+			ID        string `sqlike:",primary_key"`
+			Name      string
+			CreatedAt time.Time `sqlike:",auto_create_time"`
+			UpdatedAt time.Time `sqlike:",auto_update_time"`
+		}
+		stmt := sqlstmt.NewStatement(pg)
+		err := pg.InsertInto(
+			stmt, "db", "t", "$Key", reflext.DefaultMapper, codec.DefaultRegistry,
+			reflext.DefaultMapper.CodecByType(reflect.TypeOf(user{})).Properties(),
+			reflect.ValueOf([]user{{ID: "1", Name: "a"}}),
+			options.Insert().SetMode(options.InsertOnDuplicate),
+		)
+		require.NoError(t, err)
+		// the created time of the existing record shouldn't be overwritten
+		require.Equal(t, `INSERT INTO "db"."t" ("ID","Name","CreatedAt","UpdatedAt") VALUES ($1,$2,$3,$4) ON CONFLICT ("ID") DO UPDATE SET "Name"=EXCLUDED."Name","UpdatedAt"=EXCLUDED."UpdatedAt";`, stmt.String())
+	}
+
+	{
+		stmt := sqlstmt.NewStatement(pg)
+		err := pg.InsertInto(stmt, "db", "t", "$Key", reflext.DefaultMapper, codec.DefaultRegistry, fields, v, options.Insert().SetOnConflict(
+			expr.ColumnValue("Counter", expr.Add(expr.Column("t", "Counter"), expr.Values("Counter"))),
+			expr.ColumnValue("Name", "b"),
+		))
+		require.NoError(t, err)
+		require.Equal(t, `INSERT INTO "db"."t" ("ID","Name","Counter") VALUES ($1,$2,$3) ON CONFLICT ("ID") DO UPDATE SET "Counter" = "t"."Counter" + EXCLUDED."Counter","Name" = $4;`, stmt.String())
+		require.Equal(t, []interface{}{"1", "a", int64(2), "b"}, stmt.Args())
+	}
+
+	{
+		type user struct {
+			ID   int64 `sqlike:",auto_increment"`
+			Name string
+		}
+		fields := reflext.DefaultMapper.CodecByType(reflect.TypeOf(user{})).Properties()
+		v := reflect.ValueOf([]user{{Name: "a"}, {ID: 10, Name: "b"}})
+
+		stmt := sqlstmt.NewStatement(pg)
+		err := pg.InsertInto(stmt, "db", "t", "$Key", reflext.DefaultMapper, codec.DefaultRegistry, fields, v, options.Insert())
+		require.NoError(t, err)
+		require.Equal(t, `INSERT INTO "db"."t" ("ID","Name") VALUES (DEFAULT,$1),($2,$3) RETURNING "ID";`, stmt.String())
+		require.Equal(t, []interface{}{"a", int64(10), "b"}, stmt.Args())
+	}
+}
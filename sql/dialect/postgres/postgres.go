@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"github.com/RevenueMonster/sqlike/sql/codec"
+	"github.com/RevenueMonster/sqlike/sql/dialect"
+	"github.com/RevenueMonster/sqlike/sql/schema"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	sqlutil "github.com/RevenueMonster/sqlike/sql/util"
+)
+
+// Postgres :
+type Postgres struct {
+	schema *schema.Builder
+	parser *sqlstmt.StatementBuilder
+	sqlutil.PostgresUtil
+}
+
+var _ dialect.Dialect = (*(Postgres))(nil)
+
+// New :
+func New() *Postgres {
+	sb := schema.NewBuilder()
+	pr := sqlstmt.NewStatementBuilder()
+
+	postgresSchema{}.SetBuilders(sb)
+	postgresBuilder{}.SetRegistryAndBuilders(codec.DefaultRegistry, pr)
+
+	return &Postgres{
+		schema: sb,
+		parser: pr,
+	}
+}
+
+// GetVersion : postgres returns something like `14.5 (Debian 14.5-1.pgdg110+1)`, so we only take the first part
+func (pg Postgres) GetVersion(stmt sqlstmt.Stmt) {
+	stmt.WriteString("SELECT SPLIT_PART(CURRENT_SETTING('server_version'), ' ', 1);")
+}
@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"fmt"
+	"testing"
+
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVersion(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+	pg.GetVersion(stmt)
+	require.Equal(t, "SELECT SPLIT_PART(CURRENT_SETTING('server_version'), ' ', 1);", stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
+
+func TestDebugStatement(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+	stmt.WriteString(`SELECT * FROM "t" WHERE "a" = $1 AND "b" IN ($2,$3,$4,$5,$6,$7,$8,$9,$10,$11);`)
+	stmt.AppendArgs(int64(1), "it's", int64(3), int64(4), int64(5), int64(6), int64(7), int64(8), int64(9), int64(10), true)
+	require.Equal(t, `SELECT * FROM "t" WHERE "a" = 1 AND "b" IN ('it''s',3,4,5,6,7,8,9,10,TRUE);`, fmt.Sprintf("%+v", stmt))
+}
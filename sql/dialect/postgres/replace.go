@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/RevenueMonster/sqlike/sql"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+)
+
+// Replace : postgres doesn't have `REPLACE` statement, it's emulate using `INSERT ... ON CONFLICT` on primary key
+func (pg Postgres) Replace(stmt sqlstmt.Stmt, db, table string, columns []string, query *sql.SelectStmt) (err error) {
+	if len(columns) < 1 {
+		return errors.New("postgres: replace requires the columns")
+	}
+	stmt.WriteString("INSERT INTO ")
+	stmt.WriteString(pg.TableName(db, table) + " ")
+	stmt.WriteByte('(')
+	for i, col := range columns {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteString(pg.Quote(col))
+	}
+	stmt.WriteByte(')')
+	stmt.WriteByte(' ')
+	err = pg.parser.BuildStatement(stmt, query)
+	if err != nil {
+		return
+	}
+	stmt.WriteString(" ON CONFLICT ON CONSTRAINT " + pg.Quote(table+"_pkey") + " DO UPDATE SET ")
+	for i, col := range columns {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		column := pg.Quote(col)
+		stmt.WriteString(column + "=EXCLUDED." + column)
+	}
+	stmt.WriteByte(';')
+	return
+}
@@ -0,0 +1,361 @@
+package postgres
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/schema"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	sqltype "github.com/RevenueMonster/sqlike/sql/type"
+	sqlutil "github.com/RevenueMonster/sqlike/sql/util"
+	"github.com/RevenueMonster/sqlike/sqlike/columns"
+	"github.com/RevenueMonster/sqlike/util"
+	"golang.org/x/text/currency"
+)
+
+// identity is the postgres way of auto increment
+const identity = "GENERATED BY DEFAULT AS IDENTITY"
+
+// postgresSchema :
+type postgresSchema struct {
+	sqlutil.PostgresUtil
+}
+
+// SetBuilders :
+func (s postgresSchema) SetBuilders(sb *schema.Builder) {
+	sb.SetTypeBuilder(sqltype.Byte, s.ByteDataType)
+	sb.SetTypeBuilder(sqltype.Date, s.DateDataType)
+	sb.SetTypeBuilder(sqltype.Time, s.TimeDataType)
+	sb.SetTypeBuilder(sqltype.DateTime, s.DateTimeDataType)
+	sb.SetTypeBuilder(sqltype.Timestamp, s.DateTimeDataType)
+	sb.SetTypeBuilder(sqltype.UUID, s.UUIDDataType)
+	sb.SetTypeBuilder(sqltype.JSON, s.JSONDataType)
+	sb.SetTypeBuilder(sqltype.Point, s.SpatialDataType("POINT"))
+	sb.SetTypeBuilder(sqltype.LineString, s.SpatialDataType("LINESTRING"))
+	sb.SetTypeBuilder(sqltype.Polygon, s.SpatialDataType("POLYGON"))
+	sb.SetTypeBuilder(sqltype.MultiPoint, s.SpatialDataType("MULTIPOINT"))
+	sb.SetTypeBuilder(sqltype.MultiLineString, s.SpatialDataType("MULTILINESTRING"))
+	sb.SetTypeBuilder(sqltype.MultiPolygon, s.SpatialDataType("MULTIPOLYGON"))
+	sb.SetTypeBuilder(sqltype.String, s.StringDataType)
+	sb.SetTypeBuilder(sqltype.Char, s.CharDataType)
+	sb.SetTypeBuilder(sqltype.Bool, s.BoolDataType)
+	sb.SetTypeBuilder(sqltype.Int, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Int8, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Int16, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Int32, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Int64, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Uint, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Uint8, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Uint16, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Uint32, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Uint64, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Float32, s.FloatDataType)
+	sb.SetTypeBuilder(sqltype.Float64, s.FloatDataType)
+	sb.SetTypeBuilder(sqltype.Struct, s.JSONDataType)
+	sb.SetTypeBuilder(sqltype.Array, s.ArrayDataType)
+	sb.SetTypeBuilder(sqltype.Slice, s.JSONDataType)
+	sb.SetTypeBuilder(sqltype.Map, s.JSONDataType)
+}
+
+func (s postgresSchema) ByteDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.DataType = "BYTEA"
+	col.Type = "BYTEA"
+	col.Nullable = sf.IsNullable()
+	tag := sf.Tag()
+	if v, ok := tag.LookUp("default"); ok {
+		col.DefaultValue = &v
+	}
+	return
+}
+
+func (s postgresSchema) UUIDDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.DataType = "UUID"
+	col.Type = "UUID"
+	col.Nullable = sf.IsNullable()
+	return
+}
+
+func (s postgresSchema) DateDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.DataType = "DATE"
+	col.Type = "DATE"
+	col.Nullable = sf.IsNullable()
+	return
+}
+
+func (s postgresSchema) TimeDataType(sf reflext.StructFielder) (col columns.Column) {
+	size := "6"
+	if v, exists := sf.Tag().LookUp("size"); exists {
+		if _, err := strconv.Atoi(v); err == nil {
+			size = v
+		}
+	}
+
+	col.Name = sf.Name()
+	col.DataType = "TIME"
+	col.Type = "TIME(" + size + ")"
+	col.Nullable = sf.IsNullable()
+	return
+}
+
+// DateTimeDataType : postgres doesn't have `ON UPDATE` for column, so `on_update` and `auto_update_time` tags are ignored
+func (s postgresSchema) DateTimeDataType(sf reflext.StructFielder) (col columns.Column) {
+	size := "6"
+	if v, exists := sf.Tag().LookUp("size"); exists {
+		if _, err := strconv.Atoi(v); err == nil {
+			size = v
+		}
+	}
+
+	dflt := "CURRENT_TIMESTAMP(" + size + ")"
+	col.Name = sf.Name()
+	col.DataType = "TIMESTAMP"
+	col.Type = "TIMESTAMP(" + size + ")"
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	return
+}
+
+func (s postgresSchema) JSONDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.DataType = "JSONB"
+	col.Type = "JSONB"
+	col.Nullable = sf.IsNullable()
+	return
+}
+
+// SpatialDataType : spatial data type require `PostGIS` extension
+func (s postgresSchema) SpatialDataType(dataType string) schema.DataTypeFunc {
+	return func(sf reflext.StructFielder) (col columns.Column) {
+		col.Name = sf.Name()
+		col.DataType = "GEOMETRY"
+		col.Type = "GEOMETRY(" + dataType + ")"
+		if sf.Type().Kind() == reflect.Ptr {
+			col.Nullable = true
+		}
+		if v, ok := sf.Tag().LookUp("srid"); ok {
+			if _, err := strconv.ParseUint(v, 10, 64); err != nil {
+				return
+			}
+			col.Type = "GEOMETRY(" + dataType + "," + v + ")"
+		}
+		return
+	}
+}
+
+func (s postgresSchema) StringDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.Nullable = sf.IsNullable()
+
+	dflt := ""
+	tag := sf.Tag()
+	col.DefaultValue = &dflt
+	if v, ok := tag.LookUp("default"); ok {
+		col.DefaultValue = &v
+	}
+
+	if enum, ok := tag.LookUp("enum"); ok {
+		paths := strings.Split(enum, "|")
+		if len(paths) < 1 {
+			panic("invalid enum formats")
+		}
+
+		// postgres enum required `CREATE TYPE`, so we use check constraint instead
+		blr := util.AcquireString()
+		defer util.ReleaseString(blr)
+		blr.WriteString("VARCHAR(191) CHECK (")
+		blr.WriteString(s.Quote(col.Name))
+		blr.WriteString(" IN (")
+		for i, p := range paths {
+			if i > 0 {
+				blr.WriteRune(',')
+			}
+			blr.WriteString(s.Wrap(p))
+		}
+		blr.WriteString("))")
+
+		dflt = paths[0]
+		col.DataType = "VARCHAR"
+		col.Type = blr.String()
+		col.DefaultValue = &dflt
+		return
+	} else if char, ok := tag.LookUp("char"); ok {
+		if _, err := strconv.Atoi(char); err != nil {
+			panic("invalid value for char data type")
+		}
+		col.DataType = "CHAR"
+		col.Type = "CHAR(" + char + ")"
+		return
+	} else if _, ok := tag.LookUp("longtext"); ok {
+		col.DataType = "TEXT"
+		col.Type = "TEXT"
+		col.DefaultValue = nil
+		return
+	}
+
+	size, _ := tag.LookUp("size")
+	charLen, _ := strconv.Atoi(size)
+	if charLen < 1 {
+		charLen = 191
+	}
+
+	col.DataType = "VARCHAR"
+	col.Type = "VARCHAR(" + strconv.Itoa(charLen) + ")"
+	return
+}
+
+func (s postgresSchema) CharDataType(sf reflext.StructFielder) (col columns.Column) {
+	dflt := ""
+	switch sf.Type() {
+	case reflect.TypeOf(currency.Unit{}):
+		col.Type = "CHAR(3)"
+	default:
+		col.Type = "CHAR(191)"
+	}
+	col.Name = sf.Name()
+	col.DataType = "CHAR"
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	return
+}
+
+func (s postgresSchema) BoolDataType(sf reflext.StructFielder) (col columns.Column) {
+	dflt := "FALSE"
+	col.Name = sf.Name()
+	col.DataType = "BOOLEAN"
+	col.Type = "BOOLEAN"
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	return
+}
+
+func (s postgresSchema) IntDataType(sf reflext.StructFielder) (col columns.Column) {
+	t := sf.Type()
+	tag := sf.Tag()
+	dflt := "0"
+	dataType := s.getIntDataType(reflext.Deref(t))
+
+	col.Name = sf.Name()
+	col.DataType = dataType
+	col.Type = dataType
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	if _, ok := tag.LookUp("auto_increment"); ok {
+		col.Extra = identity
+		col.DefaultValue = nil
+	} else if v, ok := tag.LookUp("default"); ok {
+		if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+			panic("int default value should be integer")
+		}
+		col.DefaultValue = &v
+	}
+	return
+}
+
+// UintDataType : postgres doesn't support unsigned integer, so we will use the next larger data type and add a check constraint
+func (s postgresSchema) UintDataType(sf reflext.StructFielder) (col columns.Column) {
+	t := sf.Type()
+	tag := sf.Tag()
+	dflt := "0"
+	dataType := s.getUintDataType(reflext.Deref(t))
+
+	col.Name = sf.Name()
+	col.DataType = dataType
+	col.Type = dataType + " CHECK (" + s.Quote(col.Name) + " >= 0)"
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	if _, ok := tag.LookUp("auto_increment"); ok {
+		col.Type = dataType
+		col.Extra = identity
+		col.DefaultValue = nil
+	} else if v, ok := tag.LookUp("default"); ok {
+		if _, err := strconv.ParseUint(v, 10, 64); err != nil {
+			panic("uint default value should be unsigned integer")
+		}
+		col.DefaultValue = &v
+	}
+	return
+}
+
+func (s postgresSchema) FloatDataType(sf reflext.StructFielder) (col columns.Column) {
+	dflt := "0"
+	tag := sf.Tag()
+	col.Name = sf.Name()
+	col.DataType = "DOUBLE PRECISION"
+	col.Type = "DOUBLE PRECISION"
+	if _, ok := tag.LookUp("unsigned"); ok {
+		col.Type += " CHECK (" + s.Quote(col.Name) + " >= 0)"
+	}
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	if v, ok := tag.LookUp("default"); ok {
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			panic("float default value should be decimal number")
+		}
+		col.DefaultValue = &v
+	}
+	return
+}
+
+func (s postgresSchema) ArrayDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.Nullable = sf.IsNullable()
+	t := sf.Type().Elem()
+	if t.Kind() == reflect.Uint8 {
+		col.DataType = "VARCHAR"
+		col.Type = "VARCHAR(36)"
+		return
+	}
+	col.DataType = "JSONB"
+	col.Type = "JSONB"
+	return
+}
+
+// buildSchemaByColumn : charset and collation is defined on database level in postgres, so it's ignored here
+func (pg Postgres) buildSchemaByColumn(stmt sqlstmt.Stmt, col columns.Column) {
+	stmt.WriteString(pg.Quote(col.Name))
+	stmt.WriteString(" " + col.Type)
+	if col.Extra != "" {
+		stmt.WriteString(" " + col.Extra)
+	}
+	if !col.Nullable {
+		stmt.WriteString(" NOT NULL")
+		if col.DefaultValue != nil {
+			stmt.WriteString(" DEFAULT " + pg.WrapOnlyValue(*col.DefaultValue))
+		}
+	}
+}
+
+func (s postgresSchema) getIntDataType(t reflect.Type) (dataType string) {
+	switch t.Kind() {
+	case reflect.Int8, reflect.Int16:
+		dataType = "SMALLINT"
+	case reflect.Int32:
+		dataType = "INTEGER"
+	case reflect.Int64:
+		dataType = "BIGINT"
+	default:
+		dataType = "INTEGER"
+	}
+	return
+}
+
+func (s postgresSchema) getUintDataType(t reflect.Type) (dataType string) {
+	switch t.Kind() {
+	case reflect.Uint8:
+		dataType = "SMALLINT"
+	case reflect.Uint16:
+		dataType = "INTEGER"
+	case reflect.Uint32:
+		dataType = "BIGINT"
+	case reflect.Uint64:
+		dataType = "NUMERIC(20)"
+	default:
+		dataType = "BIGINT"
+	}
+	return
+}
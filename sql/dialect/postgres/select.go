@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/actions"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+)
+
+// Select :
+func (pg *Postgres) Select(stmt sqlstmt.Stmt, f *actions.FindActions, mode options.LockMode) (err error) {
+	err = pg.parser.BuildStatement(stmt, f)
+	if err != nil {
+		return
+	}
+	lck := options.Lock{Mode: mode, Of: f.LockOf, Wait: f.LockWait}
+	switch lck.Mode {
+	case options.LockForUpdate:
+		stmt.WriteString(" FOR UPDATE")
+		pg.appendLockOption(stmt, lck)
+	case options.LockForRead, options.LockForShare:
+		stmt.WriteString(" FOR SHARE")
+		pg.appendLockOption(stmt, lck)
+	}
+	stmt.WriteByte(';')
+	return
+}
+
+func (pg *Postgres) appendLockOption(stmt sqlstmt.Stmt, lck options.Lock) {
+	if len(lck.Of) > 0 {
+		stmt.WriteString(" OF ")
+		for i, tb := range lck.Of {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			stmt.WriteString(pg.Quote(tb))
+		}
+	}
+	switch lck.Wait {
+	case options.NoWait:
+		stmt.WriteString(" NOWAIT")
+	case options.SkipLocked:
+		stmt.WriteString(" SKIP LOCKED")
+	}
+}
+
+// SelectStmt :
+func (pg *Postgres) SelectStmt(stmt sqlstmt.Stmt, query interface{}) (err error) {
+	err = pg.parser.BuildStatement(stmt, query)
+	stmt.WriteByte(';')
+	return
+}
+
+func buildStatement(stmt sqlstmt.Stmt, parser *sqlstmt.StatementBuilder, f interface{}) error {
+	if err := parser.BuildStatement(stmt, f); err != nil {
+		return err
+	}
+	stmt.WriteByte(';')
+	return nil
+}
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RevenueMonster/sqlike/sql"
+	"github.com/RevenueMonster/sqlike/sql/expr"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/actions"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+	"github.com/RevenueMonster/sqlike/sqlike/primitive"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelect(t *testing.T) {
+	var (
+		now = time.Now()
+		err error
+	)
+
+	{
+		stmt := sqlstmt.AcquireStmt(Postgres{})
+		defer sqlstmt.ReleaseStmt(stmt)
+		err = New().Select(
+			stmt,
+			actions.Find().From("A", "Test").
+				Where(
+					expr.Equal("A", 1),
+					expr.Like("B", "abc%"),
+					expr.Between("DateTime", now, now.Add(5*time.Minute)),
+				).
+				OrderBy(expr.Desc("A")).
+				Limit(10).
+				Offset(5).(*actions.FindActions), options.LockForRead,
+		)
+		require.NoError(t, err)
+		require.Equal(t, `SELECT * FROM "A"."Test" WHERE ("A" = $1 AND "B" LIKE $2 AND "DateTime" BETWEEN $3 AND $4) ORDER BY "A" DESC LIMIT 10 OFFSET 5 FOR SHARE;`, stmt.String())
+		require.Equal(t, 4, len(stmt.Args()))
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(Postgres{})
+		defer sqlstmt.ReleaseStmt(stmt)
+		err = New().SelectStmt(
+			stmt,
+			sql.Select(
+				expr.Column("A"),
+				expr.As(expr.Count("B"), "c"),
+			).
+				From("db", "table").
+				Where(
+					expr.Equal(expr.JSONColumn("Address", "State", "City"), "KL"),
+					expr.In("C", []string{"1", "2"}),
+				).
+				GroupBy("A").
+				OrderBy(expr.Field("A", []interface{}{"x", "y"})),
+		)
+		require.NoError(t, err)
+		require.Equal(t, `SELECT "A",(COUNT("B")) AS "c" FROM "db"."table" WHERE ("Address"#>'{State,City}' = $1 AND "C" IN ($2,$3)) GROUP BY "A" ORDER BY ARRAY_POSITION(ARRAY[$4,$5],"A");`, stmt.String())
+		require.Equal(t, []interface{}{"KL", "1", "2", "x", "y"}, stmt.Args())
+	}
+}
+
+func TestUpdateDelete(t *testing.T) {
+	pg := New()
+
+	{
+		stmt := sqlstmt.AcquireStmt(pg)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := pg.Update(stmt, &actions.UpdateActions{
+			Database:   "db",
+			Table:      "t",
+			Conditions: []interface{}{expr.Equal("A", 1)},
+			Values:     []primitive.KV{expr.ColumnValue("B", "x")},
+			Record:     1,
+		})
+		require.NoError(t, err)
+		require.Equal(t, `UPDATE "db"."t" SET "B" = $1 WHERE ctid IN (SELECT ctid FROM "db"."t" WHERE "A" = $2 LIMIT 1);`, stmt.String())
+		require.Equal(t, []interface{}{"x", int64(1)}, stmt.Args())
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(pg)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := pg.Delete(stmt, &actions.DeleteActions{
+			Database:   "db",
+			Table:      "t",
+			Conditions: []interface{}{expr.Equal("A", 1)},
+		})
+		require.NoError(t, err)
+		require.Equal(t, `DELETE FROM "db"."t" WHERE "A" = $1;`, stmt.String())
+		require.Equal(t, []interface{}{int64(1)}, stmt.Args())
+	}
+}
+
+func TestSelectWith(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.NewStatement(pg)
+	act := actions.Find().
+		With(
+			sql.WithRecursive("Tree",
+				sql.Select("ID", "Parent").From("db", "Category").Where(expr.Equal("ID", 10)),
+				sql.Select(expr.Column("c", "ID"), expr.Column("c", "Parent")).
+					From("db", "Category", expr.Raw(`"c"`)).
+					Join(expr.Raw(`"Tree" AS "t"`), expr.Equal(expr.Column("t", "Parent"), expr.Column("c", "ID"))),
+			),
+		).
+		Where(expr.NotEqual("ID", 1)).(*actions.FindActions)
+	act.Database = "db"
+	act.Table = "Tree"
+	err := pg.parser.BuildStatement(stmt, act)
+	require.NoError(t, err)
+	require.Equal(t, `WITH RECURSIVE "Tree" AS (SELECT "ID","Parent" FROM "db"."Category" WHERE "ID" = $1 UNION ALL SELECT "c"."ID","c"."Parent" FROM "db"."Category" "c" INNER JOIN "Tree" AS "t" ON "t"."Parent" = "c"."ID") SELECT * FROM "Tree" WHERE "ID" <> $2`, stmt.String())
+	require.Equal(t, 2, len(stmt.Args()))
+}
+
+func TestSelectWindow(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.NewStatement(pg)
+	err := pg.parser.BuildStatement(stmt, sql.Select(
+		expr.As(expr.OverWindow(expr.DenseRank(), "w"), "Rank"),
+		expr.As(expr.Over(expr.Lead("Amount", 1, 0), expr.Window("w")), "Next"),
+	).
+		From("db", "Sales").
+		Where(expr.Equal("Status", "PAID")).
+		Window("w", expr.Window().PartitionBy("Category").OrderBy(expr.Desc("Amount"))),
+	)
+	require.NoError(t, err)
+	require.Equal(t, `SELECT (DENSE_RANK() OVER "w") AS "Rank",(LEAD("Amount",1,$1) OVER ("w")) AS "Next" FROM "db"."Sales" WHERE "Status" = $2 WINDOW "w" AS (PARTITION BY "Category" ORDER BY "Amount" DESC)`, stmt.String())
+	require.Equal(t, 2, len(stmt.Args()))
+}
+
+func TestSelectLock(t *testing.T) {
+	stmt := sqlstmt.NewStatement(Postgres{})
+	act := actions.Find().From("db", "Job").Limit(1).(*actions.FindActions)
+	act.LockOf, act.LockWait = []string{"Job"}, options.SkipLocked
+	err := New().Select(stmt, act, options.LockForUpdate)
+	require.NoError(t, err)
+	require.Equal(t, `SELECT * FROM "db"."Job" LIMIT 1 FOR UPDATE OF "Job" SKIP LOCKED;`, stmt.String())
+}
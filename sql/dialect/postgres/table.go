@@ -0,0 +1,317 @@
+package postgres
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/driver"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sql/util"
+	"github.com/RevenueMonster/sqlike/sqlike/columns"
+	"github.com/RevenueMonster/sqlike/sqlike/indexes"
+)
+
+// HasPrimaryKey :
+func (pg Postgres) HasPrimaryKey(stmt sqlstmt.Stmt, db, table string) {
+	stmt.WriteString("SELECT COUNT(*) FROM information_schema.table_constraints ")
+	stmt.WriteString("WHERE table_schema = $1 AND table_name = $2 AND constraint_type = 'PRIMARY KEY'")
+	stmt.WriteByte(';')
+	stmt.AppendArgs(db, table)
+}
+
+// RenameTable :
+func (pg Postgres) RenameTable(stmt sqlstmt.Stmt, db, oldName, newName string) {
+	stmt.WriteString("ALTER TABLE ")
+	stmt.WriteString(pg.TableName(db, oldName))
+	stmt.WriteString(" RENAME TO ")
+	stmt.WriteString(pg.Quote(newName))
+	stmt.WriteByte(';')
+}
+
+// DropTable :
+func (pg Postgres) DropTable(stmt sqlstmt.Stmt, db, table string, exists bool) {
+	stmt.WriteString("DROP TABLE")
+	if exists {
+		stmt.WriteString(" IF EXISTS")
+	}
+	stmt.WriteByte(' ')
+	stmt.WriteString(pg.TableName(db, table) + ";")
+}
+
+// TruncateTable :
+func (pg Postgres) TruncateTable(stmt sqlstmt.Stmt, db, table string) {
+	stmt.WriteString("TRUNCATE TABLE " + pg.TableName(db, table) + ";")
+}
+
+// HasTable :
+func (pg Postgres) HasTable(stmt sqlstmt.Stmt, dbName, table string) {
+	stmt.WriteString(`SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = $1 AND table_name = $2;`)
+	stmt.AppendArgs(dbName, table)
+}
+
+// CreateTable :
+func (pg Postgres) CreateTable(stmt sqlstmt.Stmt, db, table, pk string, info driver.Info, fields []reflext.StructFielder) (err error) {
+	var (
+		col      columns.Column
+		pkk      reflext.StructFielder
+		comments = make([][2]string, 0)
+	)
+
+	fks, err := indexes.ForeignKeys(fields)
+	if err != nil {
+		return err
+	}
+
+	stmt.WriteString("CREATE TABLE " + pg.TableName(db, table) + " ")
+	stmt.WriteByte('(')
+
+	// Main columns :
+	for i, sf := range fields {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+
+		col, err = pg.schema.GetColumn(info, sf)
+		if err != nil {
+			return
+		}
+
+		tag := sf.Tag()
+		// allow primary_key tag to override
+		if _, ok := tag.LookUp("primary_key"); ok {
+			pkk = sf
+		} else if _, ok := tag.LookUp("auto_increment"); ok {
+			pkk = sf
+		} else if sf.Name() == pk && pkk == nil {
+			pkk = sf
+		}
+
+		idx := indexes.Index{Columns: indexes.Columns(sf.Name())}
+		if _, ok := tag.LookUp("unique_index"); ok {
+			stmt.WriteString("CONSTRAINT " + pg.Quote(idx.GetName()) + " UNIQUE (" + pg.Quote(sf.Name()) + ")")
+			stmt.WriteByte(',')
+		}
+
+		pg.buildSchemaByColumn(stmt, col)
+
+		if v, ok := tag.LookUp("comment"); ok {
+			if len(v) > 60 {
+				panic("maximum length of comment is 60 characters")
+			}
+			comments = append(comments, [2]string{sf.Name(), v})
+		}
+
+		// check generated columns
+		t := reflext.Deref(sf.Type())
+		if t.Kind() != reflect.Struct {
+			continue
+		}
+
+		children := sf.Children()
+		for len(children) > 0 {
+			child := children[0]
+			if name, ok := pg.lookUpGeneratedColumn(child); ok {
+				stmt.WriteByte(',')
+				col, err = pg.schema.GetColumn(info, child)
+				if err != nil {
+					return
+				}
+				if name == "" {
+					name = col.Name
+				}
+				col.Name = name
+				pg.buildGeneratedColumn(stmt, sf, child, col)
+			}
+			children = children[1:]
+			children = append(children, child.Children()...)
+		}
+
+	}
+	if pkk != nil {
+		stmt.WriteByte(',')
+		stmt.WriteString("PRIMARY KEY (" + pg.Quote(pkk.Name()) + ")")
+	}
+	for _, fk := range fks {
+		stmt.WriteByte(',')
+		pg.buildForeignKey(stmt, db, table, fk)
+	}
+	stmt.WriteByte(')')
+	stmt.WriteByte(';')
+
+	// postgres doesn't support inline column comment
+	for _, c := range comments {
+		stmt.WriteString("COMMENT ON COLUMN " + pg.TableName(db, table) + "." + pg.Quote(c[0]) + " IS " + pg.Wrap(c[1]) + ";")
+	}
+	return
+}
+
+// ColumnSchema : the column definition of the struct field
+func (pg *Postgres) ColumnSchema(info driver.Info, sf reflext.StructFielder) (columns.Column, error) {
+	return pg.schema.GetColumn(info, sf)
+}
+
+// AlterTable : postgres doesn't support column ordering, so the column will always append at the end of the table
+func (pg *Postgres) AlterTable(stmt sqlstmt.Stmt, db, table, pk string, hasPk bool, info driver.Info, fields []reflext.StructFielder, cols util.StringSlice, idxs util.StringSlice, unsafe bool) (err error) {
+	var (
+		col      columns.Column
+		pkk      reflext.StructFielder
+		idx      int
+		comments = make([][2]string, 0)
+	)
+
+	stmt.WriteString("ALTER TABLE " + pg.TableName(db, table) + " ")
+
+	for i, sf := range fields {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+
+		exists := false
+		idx = cols.IndexOf(sf.Name())
+		if idx > -1 {
+			exists = true
+			cols.Splice(idx)
+		}
+		if !hasPk {
+			// allow primary_key tag to override
+			if _, ok := sf.Tag().LookUp("primary_key"); ok {
+				pkk = sf
+			}
+			if sf.Name() == pk && pkk == nil {
+				pkk = sf
+			}
+		}
+
+		tag := sf.Tag()
+		_, ok1 := tag.LookUp("unique_index")
+		_, ok2 := tag.LookUp("auto_increment")
+		if ok1 || ok2 {
+			idx := indexes.Index{Columns: indexes.Columns(sf.Name())}
+			if idxs.IndexOf(idx.GetName()) < 0 {
+				stmt.WriteString("ADD CONSTRAINT " + pg.Quote(idx.GetName()) + " UNIQUE (" + pg.Quote(sf.Name()) + ")")
+				stmt.WriteByte(',')
+			}
+		}
+
+		col, err = pg.schema.GetColumn(info, sf)
+		if err != nil {
+			return
+		}
+		if exists {
+			pg.buildAlterColumn(stmt, col)
+		} else {
+			stmt.WriteString("ADD COLUMN ")
+			pg.buildSchemaByColumn(stmt, col)
+		}
+
+		if v, ok := sf.Tag().LookUp("comment"); ok {
+			if len(v) > 60 {
+				panic("maximum length of comment is 60 characters")
+			}
+			comments = append(comments, [2]string{sf.Name(), v})
+		}
+
+		// check generated columns
+		t := reflext.Deref(sf.Type())
+		if t.Kind() != reflect.Struct {
+			continue
+		}
+
+		children := sf.Children()
+		for len(children) > 0 {
+			child := children[0]
+			if name, ok := pg.lookUpGeneratedColumn(child); ok {
+				col, err = pg.schema.GetColumn(info, child)
+				if err != nil {
+					return
+				}
+				if name == "" {
+					name = col.Name
+				}
+				col.Name = name
+
+				// generation expression cannot be altered, it must be drop and add again
+				idx = cols.IndexOf(name)
+				if idx > -1 {
+					cols.Splice(idx)
+				} else {
+					stmt.WriteString(",ADD COLUMN ")
+					pg.buildGeneratedColumn(stmt, sf, child, col)
+				}
+			}
+			children = children[1:]
+			children = append(children, child.Children()...)
+		}
+
+	}
+
+	if pkk != nil {
+		stmt.WriteByte(',')
+		stmt.WriteString("ADD PRIMARY KEY (" + pg.Quote(pkk.Name()) + ")")
+	}
+
+	if unsafe {
+		for _, col := range cols {
+			stmt.WriteByte(',')
+			stmt.WriteString("DROP COLUMN ")
+			stmt.WriteString(pg.Quote(col))
+		}
+	}
+	stmt.WriteByte(';')
+
+	for _, c := range comments {
+		stmt.WriteString("COMMENT ON COLUMN " + pg.TableName(db, table) + "." + pg.Quote(c[0]) + " IS " + pg.Wrap(c[1]) + ";")
+	}
+	return
+}
+
+func (pg Postgres) lookUpGeneratedColumn(sf reflext.StructFielder) (string, bool) {
+	tag := sf.Tag()
+	// postgres only support stored generated column, virtual column will fallback to stored column
+	if v, ok := tag.LookUp("virtual_column"); ok {
+		return v, true
+	}
+	if v, ok := tag.LookUp("stored_column"); ok {
+		return v, true
+	}
+	return "", false
+}
+
+func (pg Postgres) buildGeneratedColumn(stmt sqlstmt.Stmt, parent, child reflext.StructFielder, col columns.Column) {
+	paths := strings.Split(strings.TrimLeft(strings.TrimPrefix(child.Name(), parent.Name()), "."), ".")
+	stmt.WriteString(pg.Quote(col.Name))
+	stmt.WriteString(" " + col.Type)
+	stmt.WriteString(" GENERATED ALWAYS AS ")
+	stmt.WriteString("((" + pg.Quote(parent.Name()) + "#>>'{" + strings.Join(paths, ",") + "}')::" + col.DataType + ")")
+	stmt.WriteString(" STORED")
+	if !col.Nullable {
+		stmt.WriteString(" NOT NULL")
+	}
+}
+
+func (pg Postgres) buildAlterColumn(stmt sqlstmt.Stmt, col columns.Column) {
+	name := pg.Quote(col.Name)
+	typ := col.Type
+	// check constraint is not allow in `ALTER COLUMN ... TYPE`
+	if idx := strings.Index(typ, " CHECK "); idx > -1 {
+		typ = typ[:idx]
+	}
+	stmt.WriteString("ALTER COLUMN " + name + " TYPE " + typ + " USING " + name + "::" + typ)
+	if col.Extra == identity {
+		// identity column shouldn't have default value and it's always not null
+		return
+	}
+	stmt.WriteString(",ALTER COLUMN " + name)
+	if col.Nullable {
+		stmt.WriteString(" DROP NOT NULL")
+	} else {
+		stmt.WriteString(" SET NOT NULL")
+	}
+	stmt.WriteString(",ALTER COLUMN " + name)
+	if !col.Nullable && col.DefaultValue != nil {
+		stmt.WriteString(" SET DEFAULT " + pg.WrapOnlyValue(*col.DefaultValue))
+	} else {
+		stmt.WriteString(" DROP DEFAULT")
+	}
+}
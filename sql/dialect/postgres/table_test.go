@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"testing"
+
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/columns"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasPrimaryKey(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	pg.HasPrimaryKey(stmt, "db", "table")
+	require.Equal(t, "SELECT COUNT(*) FROM information_schema.table_constraints WHERE table_schema = $1 AND table_name = $2 AND constraint_type = 'PRIMARY KEY';", stmt.String())
+	require.ElementsMatch(t, []interface{}{"db", "table"}, stmt.Args())
+}
+
+func TestDropTable(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	{
+		pg.DropTable(stmt, "db", "table", true)
+		require.Equal(t, `DROP TABLE IF EXISTS "db"."table";`, stmt.String())
+		require.ElementsMatch(t, []interface{}{}, stmt.Args())
+	}
+
+	stmt.Reset()
+
+	{
+		pg.DropTable(stmt, "db", "table", false)
+		require.Equal(t, `DROP TABLE "db"."table";`, stmt.String())
+		require.ElementsMatch(t, []interface{}{}, stmt.Args())
+	}
+}
+
+func TestRenameTable(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+	pg.RenameTable(stmt, "db", "oldName", "newName")
+	require.Equal(t, `ALTER TABLE "db"."oldName" RENAME TO "newName";`, stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
+
+func TestTruncateTable(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	pg.TruncateTable(stmt, "db", "table")
+	require.Equal(t, `TRUNCATE TABLE "db"."table";`, stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
+
+func TestHasTable(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	pg.HasTable(stmt, "db", "table")
+	require.Equal(t, "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = $1 AND table_name = $2;", stmt.String())
+	require.ElementsMatch(t, []interface{}{"db", "table"}, stmt.Args())
+}
+
+func TestModifyColumn(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	dflt := "'abc'::character varying"
+	pg.ModifyColumn(stmt, "db", "table", columns.Column{Name: "Name", Type: "CHARACTER VARYING(191)", DefaultValue: &dflt}, "ID")
+	require.Equal(t, `ALTER TABLE "db"."table" ALTER COLUMN "Name" TYPE CHARACTER VARYING(191) USING "Name"::CHARACTER VARYING(191),ALTER COLUMN "Name" SET NOT NULL,ALTER COLUMN "Name" SET DEFAULT 'abc'::character varying;`, stmt.String())
+
+	stmt.Reset()
+	pg.ModifyColumn(stmt, "db", "table", columns.Column{Name: "Age", Type: "INTEGER", Nullable: true}, "Name")
+	require.Equal(t, `ALTER TABLE "db"."table" ALTER COLUMN "Age" TYPE INTEGER USING "Age"::INTEGER,ALTER COLUMN "Age" DROP NOT NULL,ALTER COLUMN "Age" DROP DEFAULT;`, stmt.String())
+
+	stmt.Reset()
+	pg.ModifyColumn(stmt, "db", "table", columns.Column{Name: "ID", Type: "INTEGER", Extra: "IDENTITY"}, "")
+	require.Equal(t, `ALTER TABLE "db"."table" ALTER COLUMN "ID" TYPE INTEGER USING "ID"::INTEGER;`, stmt.String())
+}
+
+func TestDropUniqueIndex(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	pg.DropUniqueIndex(stmt, "db", "table", "UX_Email")
+	require.Equal(t, `ALTER TABLE "db"."table" DROP CONSTRAINT "UX_Email";`, stmt.String())
+
+	stmt.Reset()
+	pg.DropUniqueIndex(stmt, "db", "table", "PRIMARY")
+	require.Equal(t, `ALTER TABLE "db"."table" DROP CONSTRAINT "table_pkey";`, stmt.String())
+}
+
+func TestDropIndexes(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	{
+		pg.DropIndexes(stmt, "db", "table", []string{"PRIMARY", "IX_Name", "table_pkey", "UX_Email"})
+		require.Equal(t, `DROP INDEX "db"."IX_Name","db"."UX_Email";`, stmt.String())
+	}
+
+	stmt.Reset()
+
+	// nothing to drop
+	{
+		pg.DropIndexes(stmt, "db", "table", []string{"PRIMARY", "table_pkey"})
+		require.Equal(t, "", stmt.String())
+	}
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/RevenueMonster/sqlike/util"
+)
+
+// Format :
+func (pg Postgres) Format(it interface{}) (val string) {
+	switch vi := it.(type) {
+	case []byte:
+		val = pg.Wrap(util.UnsafeString(vi))
+	case string:
+		val = pg.Wrap(vi)
+	case bool:
+		val = "FALSE"
+		if vi {
+			val = "TRUE"
+		}
+	case int64:
+		val = strconv.FormatInt(vi, 10)
+	case uint64:
+		val = strconv.FormatUint(vi, 10)
+	case float64:
+		val = strconv.FormatFloat(vi, 'e', -1, 64)
+	case time.Time:
+		val = vi.Format(`'2006-01-02 15:04:05.999999'`)
+	case json.RawMessage:
+		val = pg.Wrap(util.UnsafeString(vi))
+	case sql.RawBytes:
+		val = string(vi)
+	case nil:
+		val = "NULL"
+	case fmt.Stringer:
+		val = pg.Wrap(vi.String())
+	case driver.Valuer:
+		v, _ := vi.Value()
+		val = pg.Format(v)
+	default:
+		val = fmt.Sprintf("%v", vi)
+	}
+	return
+}
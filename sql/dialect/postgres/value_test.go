@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	var (
+		pg  = New()
+		str string
+	)
+
+	str = pg.Format(int64(-638731231286))
+	require.Equal(t, "-638731231286", str)
+
+	str = pg.Format(uint64(638731231286))
+	require.Equal(t, "638731231286", str)
+
+	str = pg.Format("hello world")
+	require.Equal(t, `'hello world'`, str)
+
+	str = pg.Format("it's")
+	require.Equal(t, `'it''s'`, str)
+
+	str = pg.Format(true)
+	require.Equal(t, "TRUE", str)
+
+	str = pg.Format(false)
+	require.Equal(t, "FALSE", str)
+
+	str = pg.Format(nil)
+	require.Equal(t, "NULL", str)
+
+	str = pg.Format(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.Equal(t, `'2020-01-02 03:04:05'`, str)
+}
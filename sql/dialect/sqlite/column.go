@@ -0,0 +1,27 @@
+package sqlite
+
+import sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+
+// GetColumns :
+func (s *SQLite) GetColumns(stmt sqlstmt.Stmt, dbName, table string) {
+	stmt.WriteString(`SELECT cid + 1, name, type, dflt_value,
+	CASE WHEN "notnull" = 1 THEN 'NO' ELSE 'YES' END,
+	CASE WHEN INSTR(type, '(') > 0 THEN SUBSTR(type, 1, INSTR(type, '(') - 1) ELSE type END,
+	NULL, NULL, '', ''
+	FROM pragma_table_info(?) ORDER BY cid;`)
+	stmt.AppendArgs(table)
+}
+
+// RenameColumn : this require sqlite 3.25.0 and above
+func (s *SQLite) RenameColumn(stmt sqlstmt.Stmt, db, table, oldColName, newColName string) {
+	stmt.WriteString("ALTER TABLE " + s.TableName(db, table))
+	stmt.WriteString(" RENAME COLUMN " + s.Quote(oldColName) + " TO " + s.Quote(newColName))
+	stmt.WriteByte(';')
+}
+
+// DropColumn : this require sqlite 3.35.0 and above
+func (s *SQLite) DropColumn(stmt sqlstmt.Stmt, db, table, column string) {
+	stmt.WriteString("ALTER TABLE " + s.TableName(db, table))
+	stmt.WriteString(" DROP COLUMN " + s.Quote(column))
+	stmt.WriteByte(';')
+}
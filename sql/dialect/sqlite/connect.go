@@ -0,0 +1,21 @@
+package sqlite
+
+import (
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+)
+
+// Connect : sqlite connection string is the path of the database file, the host will be use as the file path
+// and it will fallback to shared in-memory database if it's empty
+func (s SQLite) Connect(opt *options.ConnectOptions) (connStr string) {
+	if opt.RawConnStr() != "" {
+		connStr = opt.RawConnStr()
+		return
+	}
+
+	if opt.Host == "" {
+		connStr = "file::memory:?cache=shared"
+		return
+	}
+	connStr = "file:" + opt.Host
+	return
+}
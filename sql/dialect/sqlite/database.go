@@ -0,0 +1,29 @@
+package sqlite
+
+import (
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+)
+
+// noop : sqlite database is bind to a file, there is nothing to do on database level,
+// but the statement still need to be executable
+const noop = "SELECT 1;"
+
+// UseDatabase :
+func (s SQLite) UseDatabase(stmt sqlstmt.Stmt, db string) {
+	stmt.WriteString(noop)
+}
+
+// CreateDatabase :
+func (s SQLite) CreateDatabase(stmt sqlstmt.Stmt, db string, checkExists bool) {
+	stmt.WriteString(noop)
+}
+
+// DropDatabase :
+func (s SQLite) DropDatabase(stmt sqlstmt.Stmt, db string, checkExists bool) {
+	stmt.WriteString(noop)
+}
+
+// GetDatabases :
+func (s SQLite) GetDatabases(stmt sqlstmt.Stmt) {
+	stmt.WriteString("SELECT name FROM pragma_database_list;")
+}
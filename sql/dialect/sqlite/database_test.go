@@ -0,0 +1,26 @@
+package sqlite
+
+import (
+	"testing"
+
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseDatabase(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+	s.UseDatabase(stmt, "db")
+	require.Equal(t, "SELECT 1;", stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
+
+func TestGetDatabases(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+	s.GetDatabases(stmt)
+	require.Equal(t, "SELECT name FROM pragma_database_list;", stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
@@ -0,0 +1,15 @@
+package sqlite
+
+import (
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/actions"
+)
+
+// Delete :
+func (s *SQLite) Delete(stmt sqlstmt.Stmt, f *actions.DeleteActions) (err error) {
+	err = buildStatement(stmt, s.parser, f)
+	if err != nil {
+		return
+	}
+	return
+}
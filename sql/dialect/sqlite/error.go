@@ -0,0 +1,13 @@
+package sqlite
+
+import "strings"
+
+// IsRetryableError : the database is locked by another connection (`SQLITE_BUSY` or `SQLITE_LOCKED`), so it's safe to be retried.
+// The error code is not exposed without the driver, so the error message is checked instead.
+func (s SQLite) IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "database is locked") || strings.Contains(msg, "database table is locked")
+}
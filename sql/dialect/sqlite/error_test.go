@@ -0,0 +1,16 @@
+package sqlite
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	s := New()
+	require.True(t, s.IsRetryableError(errors.New("database is locked")))
+	require.True(t, s.IsRetryableError(errors.New("database table is locked: User")))
+	require.False(t, s.IsRetryableError(errors.New("UNIQUE constraint failed")))
+	require.False(t, s.IsRetryableError(nil))
+}
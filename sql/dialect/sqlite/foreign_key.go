@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"strings"
+
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/indexes"
+)
+
+// GetForeignKeys : sqlite foreign key is unnamed, so we follow the default naming convention `FK_<table>_<column>`
+func (s SQLite) GetForeignKeys(stmt sqlstmt.Stmt, db, table string) {
+	stmt.WriteString(`SELECT 'FK_' || ? || '_' || "from", "from", "table", "to", on_delete, on_update FROM pragma_foreign_key_list(?) ORDER BY id, seq;`)
+	stmt.AppendArgs(table, table)
+}
+
+// AddForeignKeys : sqlite doesn't support adding constraint using `ALTER TABLE`, foreign key can only be declared on `CREATE TABLE`
+func (s SQLite) AddForeignKeys(stmt sqlstmt.Stmt, db, table string, fks []indexes.ForeignKey) {}
+
+// DropForeignKeys : sqlite doesn't support dropping constraint using `ALTER TABLE`
+func (s SQLite) DropForeignKeys(stmt sqlstmt.Stmt, db, table string, names []string) {}
+
+func (s SQLite) buildForeignKey(stmt sqlstmt.Stmt, table string, fk indexes.ForeignKey) {
+	stmt.WriteString("CONSTRAINT " + s.Quote(fk.GetName(table)))
+	stmt.WriteString(" FOREIGN KEY (" + s.quoteAll(fk.Columns) + ")")
+	stmt.WriteString(" REFERENCES " + s.Quote(fk.RefTable) + " (" + s.quoteAll(fk.RefColumns) + ")")
+	if fk.OnDelete != "" {
+		stmt.WriteString(" ON DELETE " + fk.OnDelete)
+	}
+	if fk.OnUpdate != "" {
+		stmt.WriteString(" ON UPDATE " + fk.OnUpdate)
+	}
+}
+
+func (s SQLite) quoteAll(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = s.Quote(n)
+	}
+	return strings.Join(quoted, ",")
+}
@@ -0,0 +1,85 @@
+package sqlite
+
+import (
+	"strings"
+
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/indexes"
+)
+
+// HasIndexByName :
+func (s SQLite) HasIndexByName(stmt sqlstmt.Stmt, dbName, table, indexName string) {
+	stmt.WriteString(`SELECT COUNT(1) FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND name = ?;`)
+	stmt.AppendArgs(table, indexName)
+}
+
+// HasIndex :
+func (s SQLite) HasIndex(stmt sqlstmt.Stmt, dbName, table string, idx indexes.Index) {
+	unique := 0
+	switch idx.Type {
+	case indexes.Unique, indexes.Primary:
+		unique = 1
+	}
+	args := []interface{}{table, unique}
+	stmt.WriteString("SELECT COUNT(1) FROM (")
+	stmt.WriteString("SELECT il.name FROM pragma_index_list(?) AS il ")
+	stmt.WriteString("JOIN pragma_index_info(il.name) AS ii ")
+	stmt.WriteString(`WHERE il."unique" = ? `)
+	stmt.WriteString("AND ii.name IN ")
+	stmt.WriteByte('(')
+	for i, col := range idx.Columns {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteByte('?')
+		args = append(args, col.Name)
+	}
+	stmt.WriteByte(')')
+	stmt.WriteString(" GROUP BY il.name HAVING COUNT(*) = ?")
+	stmt.WriteString(") AS temp;")
+	args = append(args, int64(len(idx.Columns)))
+	stmt.AppendArgs(args...)
+}
+
+// GetIndexes :
+func (s SQLite) GetIndexes(stmt sqlstmt.Stmt, dbName, table string) {
+	stmt.WriteString(`SELECT name, 'BTREE', CASE WHEN "unique" = 1 THEN 0 ELSE 1 END FROM pragma_index_list(?);`)
+	stmt.AppendArgs(table)
+}
+
+// CreateIndexes : sqlite only support b-tree index, fulltext, spatial and multi-valued index will fallback to normal index
+func (s SQLite) CreateIndexes(stmt sqlstmt.Stmt, db, table string, idxs []indexes.Index, supportDesc bool) {
+	for _, idx := range idxs {
+		stmt.WriteString("CREATE ")
+		if idx.Type == indexes.Unique || idx.Type == indexes.Primary {
+			stmt.WriteString("UNIQUE ")
+		}
+		stmt.WriteString("INDEX " + s.Quote(idx.GetName()) + " ON " + s.TableName(db, table) + " ")
+		stmt.WriteByte('(')
+		for j, col := range idx.Columns {
+			if j > 0 {
+				stmt.WriteByte(',')
+			}
+			stmt.WriteString(s.Quote(col.Name))
+			if !supportDesc {
+				continue
+			}
+			if col.Direction == indexes.Descending {
+				stmt.WriteString(" DESC")
+			}
+		}
+		stmt.WriteByte(')')
+		stmt.WriteByte(';')
+	}
+}
+
+// DropIndexes : sqlite only allow to drop one index per statement
+func (s SQLite) DropIndexes(stmt sqlstmt.Stmt, db, table string, idxs []string) {
+	for _, idx := range idxs {
+		// index which created by `UNIQUE` or `PRIMARY KEY` constraint cannot be drop
+		if idx == "PRIMARY" || strings.HasPrefix(idx, "sqlite_autoindex_") {
+			continue
+		}
+		stmt.WriteString("DROP INDEX " + s.Quote(idx) + ";")
+	}
+}
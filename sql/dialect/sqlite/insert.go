@@ -0,0 +1,227 @@
+package sqlite
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/codec"
+	"github.com/RevenueMonster/sqlike/sql/dialect"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sql/util"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+	"github.com/RevenueMonster/sqlike/sqlike/primitive"
+)
+
+// InsertInto :
+func (s SQLite) InsertInto(stmt sqlstmt.Stmt, db, table, pk string, cache reflext.StructMapper, cdc codec.Codecer, fields []reflext.StructFielder, v reflect.Value, opt *options.InsertOptions) (err error) {
+	records := v.Len()
+
+	stmt.WriteString("INSERT")
+	if opt.Mode == options.InsertIgnore {
+		stmt.WriteString(" OR IGNORE")
+	}
+	stmt.WriteString(" INTO " + s.TableName(db, table) + " (")
+
+	omitField := make(map[string]bool)
+	noOfOmit := len(opt.Omits)
+	for i := 0; i < len(fields); {
+		// omit all the field provided by user
+		if noOfOmit > 0 && opt.Omits.IndexOf(fields[i].Name()) > -1 {
+			if opt.Mode != options.InsertOnDuplicate {
+				fields = append(fields[:i], fields[i+1:]...)
+				continue
+			} else {
+				omitField[fields[i].Name()] = true
+			}
+		}
+
+		// omit all the struct field with `generated_column` tag, it shouldn't include when inserting to the db
+		if _, ok := fields[i].Tag().LookUp("generated_column"); ok {
+			fields = append(fields[:i], fields[i+1:]...)
+			continue
+		}
+
+		stmt.WriteString(s.Quote(fields[i].Name()))
+		if i < len(fields)-1 {
+			stmt.WriteByte(',')
+		}
+
+		i++
+	}
+	stmt.WriteString(") VALUES ")
+
+	length := len(fields)
+	encoders := make([]codec.ValueEncoder, length)
+	for i := 0; i < records; i++ {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteByte('(')
+		vi := reflext.Indirect(v.Index(i))
+
+		for j := range fields {
+			if j > 0 {
+				stmt.WriteByte(',')
+			}
+
+			// first record only find encoders
+			fv := cache.FieldByIndexesReadOnly(vi, fields[j].Index())
+			if i == 0 {
+				encoders[j], err = findEncoder(cdc, fields[j], fv)
+				if err != nil {
+					return err
+				}
+			}
+
+			val, err := encoders[j](fields[j], fv)
+			if err != nil {
+				return err
+			}
+
+			if err := convertSpatial(stmt, s.SQLiteUtil, val); err != nil {
+				return err
+			}
+		}
+		stmt.WriteByte(')')
+	}
+
+	switch opt.Mode {
+	case options.InsertOnDuplicate:
+		stmt.WriteString(" ON CONFLICT (" + s.Quote(conflictKey(fields, pk)) + ")")
+		if len(opt.OnConflict) > 0 {
+			stmt.WriteString(" DO UPDATE SET ")
+			if err := s.appendOnConflict(stmt, fields, opt.OnConflict); err != nil {
+				return err
+			}
+			break
+		}
+		columns := updateColumns(fields, pk, omitField, opt.Keeps)
+		// keep all the existing values
+		if len(columns) == 0 {
+			stmt.WriteString(" DO NOTHING")
+			break
+		}
+		stmt.WriteString(" DO UPDATE SET ")
+		for i, name := range columns {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			column := s.Quote(name)
+			stmt.WriteString(column + "=EXCLUDED." + column)
+		}
+	}
+	// return the generated value of `auto_increment` column, `RETURNING` is supported since sqlite 3.35
+	for _, f := range fields {
+		if _, ok := f.Tag().LookUp("auto_increment"); ok {
+			stmt.WriteString(" RETURNING " + s.Quote(f.Name()))
+			break
+		}
+	}
+	stmt.WriteByte(';')
+	return
+}
+
+// InsertIDMode : the generated values are returned using `RETURNING` clause instead of `LastInsertId`
+func (s SQLite) InsertIDMode() dialect.InsertIDMode {
+	return dialect.ReturningInsertID
+}
+
+func (s SQLite) appendOnConflict(stmt sqlstmt.Stmt, fields []reflext.StructFielder, values []interface{}) error {
+	for i, v := range values {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		switch vi := v.(type) {
+		case string:
+			if !hasField(fields, vi) {
+				return fmt.Errorf("sqlite: invalid on conflict column %q", vi)
+			}
+			column := s.Quote(vi)
+			stmt.WriteString(column + "=EXCLUDED." + column)
+		case primitive.KV:
+			if err := s.parser.BuildStatement(stmt, vi); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("sqlite: invalid on conflict value %T", v)
+		}
+	}
+	return nil
+}
+
+// updateColumns : columns to update on conflict, primary key, auto increment, auto create time, omitted and kept columns will be skipped
+func updateColumns(fields []reflext.StructFielder, pk string, omitField map[string]bool, keeps util.StringSlice) []string {
+	columns := make([]string, 0, len(fields))
+	for _, f := range fields {
+		name := f.Name()
+		// skip primary key on duplicate update
+		if name == pk {
+			continue
+		}
+
+		// skip primary key on duplicate update
+		if _, ok := f.Tag().LookUp("primary_key"); ok {
+			continue
+		}
+
+		if _, ok := f.Tag().LookUp("auto_increment"); ok {
+			continue
+		}
+
+		// keep the created time of the existing record
+		if _, ok := f.Tag().LookUp("auto_create_time"); ok {
+			continue
+		}
+
+		// skip omit fields on update
+		if _, ok := omitField[name]; ok {
+			continue
+		}
+
+		// keep existing value on update
+		if keeps.IndexOf(name) > -1 {
+			continue
+		}
+
+		columns = append(columns, name)
+	}
+	return columns
+}
+
+func hasField(fields []reflext.StructFielder, name string) bool {
+	for _, f := range fields {
+		if f.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// conflictKey : sqlite requires the conflict target for `DO UPDATE`, it will be the primary key of the table
+func conflictKey(fields []reflext.StructFielder, pk string) string {
+	for _, f := range fields {
+		if _, ok := f.Tag().LookUp("primary_key"); ok {
+			return f.Name()
+		}
+	}
+	return pk
+}
+
+func findEncoder(c codec.Codecer, sf reflext.StructFielder, v reflect.Value) (codec.ValueEncoder, error) {
+	encoder, err := c.LookupEncoder(v)
+	if err != nil {
+		return nil, err
+	}
+	// auto_increment field should pass nil if it's empty, it's checked on every record
+	// as the encoder is only looked up using the first record
+	if _, ok := sf.Tag().LookUp("auto_increment"); ok {
+		return func(sf reflext.StructFielder, v reflect.Value) (interface{}, error) {
+			if reflext.IsZero(v) {
+				return nil, nil
+			}
+			return encoder(sf, v)
+		}, nil
+	}
+	return encoder, nil
+}
@@ -0,0 +1,278 @@
+package sqlite
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/schema"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	sqltype "github.com/RevenueMonster/sqlike/sql/type"
+	sqlutil "github.com/RevenueMonster/sqlike/sql/util"
+	"github.com/RevenueMonster/sqlike/sqlike/columns"
+	"github.com/RevenueMonster/sqlike/util"
+)
+
+// sqlite column affinities, see https://www.sqlite.org/datatype3.html
+const (
+	affinityText    = "TEXT"
+	affinityInteger = "INTEGER"
+	affinityReal    = "REAL"
+	affinityBlob    = "BLOB"
+)
+
+// sqliteSchema :
+type sqliteSchema struct {
+	sqlutil.SQLiteUtil
+}
+
+// SetBuilders :
+func (s sqliteSchema) SetBuilders(sb *schema.Builder) {
+	sb.SetTypeBuilder(sqltype.Byte, s.ByteDataType)
+	sb.SetTypeBuilder(sqltype.Date, s.DateDataType)
+	sb.SetTypeBuilder(sqltype.Time, s.TimeDataType)
+	sb.SetTypeBuilder(sqltype.DateTime, s.DateTimeDataType)
+	sb.SetTypeBuilder(sqltype.Timestamp, s.DateTimeDataType)
+	sb.SetTypeBuilder(sqltype.UUID, s.UUIDDataType)
+	sb.SetTypeBuilder(sqltype.JSON, s.JSONDataType)
+	sb.SetTypeBuilder(sqltype.Point, s.SpatialDataType)
+	sb.SetTypeBuilder(sqltype.LineString, s.SpatialDataType)
+	sb.SetTypeBuilder(sqltype.Polygon, s.SpatialDataType)
+	sb.SetTypeBuilder(sqltype.MultiPoint, s.SpatialDataType)
+	sb.SetTypeBuilder(sqltype.MultiLineString, s.SpatialDataType)
+	sb.SetTypeBuilder(sqltype.MultiPolygon, s.SpatialDataType)
+	sb.SetTypeBuilder(sqltype.String, s.StringDataType)
+	sb.SetTypeBuilder(sqltype.Char, s.CharDataType)
+	sb.SetTypeBuilder(sqltype.Bool, s.BoolDataType)
+	sb.SetTypeBuilder(sqltype.Int, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Int8, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Int16, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Int32, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Int64, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Uint, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Uint8, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Uint16, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Uint32, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Uint64, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Float32, s.FloatDataType)
+	sb.SetTypeBuilder(sqltype.Float64, s.FloatDataType)
+	sb.SetTypeBuilder(sqltype.Struct, s.JSONDataType)
+	sb.SetTypeBuilder(sqltype.Array, s.ArrayDataType)
+	sb.SetTypeBuilder(sqltype.Slice, s.JSONDataType)
+	sb.SetTypeBuilder(sqltype.Map, s.JSONDataType)
+}
+
+func (s sqliteSchema) ByteDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.DataType = affinityBlob
+	col.Type = affinityBlob
+	col.Nullable = sf.IsNullable()
+	tag := sf.Tag()
+	if v, ok := tag.LookUp("default"); ok {
+		col.DefaultValue = &v
+	}
+	return
+}
+
+func (s sqliteSchema) UUIDDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.DataType = affinityText
+	col.Type = affinityText
+	col.Nullable = sf.IsNullable()
+	return
+}
+
+// DateDataType : sqlite doesn't have date data type, it's store as ISO8601 string
+func (s sqliteSchema) DateDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.DataType = affinityText
+	col.Type = affinityText
+	col.Nullable = sf.IsNullable()
+	return
+}
+
+func (s sqliteSchema) TimeDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.DataType = affinityText
+	col.Type = affinityText
+	col.Nullable = sf.IsNullable()
+	return
+}
+
+// DateTimeDataType : sqlite doesn't have `ON UPDATE` for column, so `on_update` and `auto_update_time` tags are ignored
+func (s sqliteSchema) DateTimeDataType(sf reflext.StructFielder) (col columns.Column) {
+	dflt := "STRFTIME('%Y-%m-%d %H:%M:%f', 'now')"
+	col.Name = sf.Name()
+	col.DataType = affinityText
+	col.Type = affinityText
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	return
+}
+
+func (s sqliteSchema) JSONDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.DataType = affinityText
+	col.Type = affinityText
+	col.Nullable = sf.IsNullable()
+	return
+}
+
+// SpatialDataType : spatial is store as mysql internal format (SRID + WKB)
+func (s sqliteSchema) SpatialDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.DataType = affinityBlob
+	col.Type = affinityBlob
+	if sf.Type().Kind() == reflect.Ptr {
+		col.Nullable = true
+	}
+	return
+}
+
+func (s sqliteSchema) StringDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.Nullable = sf.IsNullable()
+	col.DataType = affinityText
+	col.Type = affinityText
+
+	dflt := ""
+	tag := sf.Tag()
+	col.DefaultValue = &dflt
+	if v, ok := tag.LookUp("default"); ok {
+		col.DefaultValue = &v
+	}
+
+	if enum, ok := tag.LookUp("enum"); ok {
+		paths := strings.Split(enum, "|")
+		if len(paths) < 1 {
+			panic("invalid enum formats")
+		}
+
+		// sqlite doesn't have enum, so we use check constraint instead
+		blr := util.AcquireString()
+		defer util.ReleaseString(blr)
+		blr.WriteString(affinityText + " CHECK (")
+		blr.WriteString(s.Quote(col.Name))
+		blr.WriteString(" IN (")
+		for i, p := range paths {
+			if i > 0 {
+				blr.WriteRune(',')
+			}
+			blr.WriteString(s.Wrap(p))
+		}
+		blr.WriteString("))")
+
+		dflt = paths[0]
+		col.Type = blr.String()
+		col.DefaultValue = &dflt
+		return
+	} else if _, ok := tag.LookUp("longtext"); ok {
+		col.DefaultValue = nil
+	}
+	return
+}
+
+func (s sqliteSchema) CharDataType(sf reflext.StructFielder) (col columns.Column) {
+	dflt := ""
+	col.Name = sf.Name()
+	col.DataType = affinityText
+	col.Type = affinityText
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	return
+}
+
+func (s sqliteSchema) BoolDataType(sf reflext.StructFielder) (col columns.Column) {
+	dflt := "0"
+	col.Name = sf.Name()
+	col.DataType = affinityInteger
+	col.Type = affinityInteger
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	return
+}
+
+// IntDataType : `auto_increment` is only applicable on primary key, it's handle when creating table
+func (s sqliteSchema) IntDataType(sf reflext.StructFielder) (col columns.Column) {
+	tag := sf.Tag()
+	dflt := "0"
+	col.Name = sf.Name()
+	col.DataType = affinityInteger
+	col.Type = affinityInteger
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	if _, ok := tag.LookUp("auto_increment"); ok {
+		col.DefaultValue = nil
+	} else if v, ok := tag.LookUp("default"); ok {
+		if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+			panic("int default value should be integer")
+		}
+		col.DefaultValue = &v
+	}
+	return
+}
+
+// UintDataType : sqlite integer is signed 64-bit, so we add a check constraint for unsigned integer
+func (s sqliteSchema) UintDataType(sf reflext.StructFielder) (col columns.Column) {
+	tag := sf.Tag()
+	dflt := "0"
+	col.Name = sf.Name()
+	col.DataType = affinityInteger
+	col.Type = affinityInteger + " CHECK (" + s.Quote(col.Name) + " >= 0)"
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	if _, ok := tag.LookUp("auto_increment"); ok {
+		col.Type = affinityInteger
+		col.DefaultValue = nil
+	} else if v, ok := tag.LookUp("default"); ok {
+		if _, err := strconv.ParseUint(v, 10, 64); err != nil {
+			panic("uint default value should be unsigned integer")
+		}
+		col.DefaultValue = &v
+	}
+	return
+}
+
+func (s sqliteSchema) FloatDataType(sf reflext.StructFielder) (col columns.Column) {
+	dflt := "0"
+	tag := sf.Tag()
+	col.Name = sf.Name()
+	col.DataType = affinityReal
+	col.Type = affinityReal
+	if _, ok := tag.LookUp("unsigned"); ok {
+		col.Type += " CHECK (" + s.Quote(col.Name) + " >= 0)"
+	}
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	if v, ok := tag.LookUp("default"); ok {
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			panic("float default value should be decimal number")
+		}
+		col.DefaultValue = &v
+	}
+	return
+}
+
+func (s sqliteSchema) ArrayDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.Nullable = sf.IsNullable()
+	col.DataType = affinityText
+	col.Type = affinityText
+	return
+}
+
+// buildSchemaByColumn : sqlite only support utf-8 encoding, so charset and collation is ignored
+func (s SQLite) buildSchemaByColumn(stmt sqlstmt.Stmt, col columns.Column) {
+	stmt.WriteString(s.Quote(col.Name))
+	stmt.WriteString(" " + col.Type)
+	if col.Extra != "" {
+		stmt.WriteString(" " + col.Extra)
+	}
+	if !col.Nullable {
+		stmt.WriteString(" NOT NULL")
+		if col.DefaultValue != nil {
+			stmt.WriteString(" DEFAULT " + s.WrapOnlyValue(*col.DefaultValue))
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package sqlite
+
+import (
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/actions"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+)
+
+// Select : sqlite is locking the whole database on write, so row locking is not applicable
+func (s *SQLite) Select(stmt sqlstmt.Stmt, f *actions.FindActions, mode options.LockMode) (err error) {
+	err = s.parser.BuildStatement(stmt, f)
+	if err != nil {
+		return
+	}
+	stmt.WriteByte(';')
+	return
+}
+
+// SelectStmt :
+func (s *SQLite) SelectStmt(stmt sqlstmt.Stmt, query interface{}) (err error) {
+	err = s.parser.BuildStatement(stmt, query)
+	stmt.WriteByte(';')
+	return
+}
+
+func buildStatement(stmt sqlstmt.Stmt, parser *sqlstmt.StatementBuilder, f interface{}) error {
+	if err := parser.BuildStatement(stmt, f); err != nil {
+		return err
+	}
+	stmt.WriteByte(';')
+	return nil
+}
@@ -0,0 +1,180 @@
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql"
+	"github.com/RevenueMonster/sqlike/sql/codec"
+	"github.com/RevenueMonster/sqlike/sql/expr"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/actions"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+	"github.com/RevenueMonster/sqlike/sqlike/primitive"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelect(t *testing.T) {
+	s := New()
+
+	{
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := s.Select(
+			stmt,
+			actions.Find().From("A", "Test").
+				Where(
+					expr.Equal("A", 1),
+					expr.Like("B", "abc%"),
+				).
+				OrderBy(expr.Field("C", []interface{}{"x", "y"})).
+				Offset(5).(*actions.FindActions), options.LockForUpdate,
+		)
+		require.NoError(t, err)
+		require.Equal(t, `SELECT * FROM "Test" WHERE ("A" = ? AND "B" LIKE ?) ORDER BY CASE "C" WHEN ? THEN 1 WHEN ? THEN 2 ELSE 0 END LIMIT -1 OFFSET 5;`, stmt.String())
+		require.Equal(t, []interface{}{int64(1), "abc%", "x", "y"}, stmt.Args())
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := s.SelectStmt(
+			stmt,
+			sql.Select(
+				expr.JSONColumn("Address", "State", "City"),
+			).
+				From("db", "table").
+				Where(
+					expr.Equal(expr.JSONColumn("Address", "$.State").WithQuote(), "KL"),
+				),
+		)
+		require.NoError(t, err)
+		require.Equal(t, `SELECT "Address"->'$.State.City' FROM "table" WHERE "Address"->>'$.State' = ?;`, stmt.String())
+		require.Equal(t, []interface{}{"KL"}, stmt.Args())
+	}
+}
+
+func TestUpdateDelete(t *testing.T) {
+	s := New()
+
+	{
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := s.Update(stmt, &actions.UpdateActions{
+			Database:   "db",
+			Table:      "t",
+			Conditions: []interface{}{expr.Equal("A", 1)},
+			Values:     []primitive.KV{expr.ColumnValue("B", "x")},
+			Record:     1,
+		})
+		require.NoError(t, err)
+		require.Equal(t, `UPDATE "t" SET "B" = ? WHERE rowid IN (SELECT rowid FROM "t" WHERE "A" = ? LIMIT 1);`, stmt.String())
+		require.Equal(t, []interface{}{"x", int64(1)}, stmt.Args())
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := s.Delete(stmt, &actions.DeleteActions{
+			Database:   "db",
+			Table:      "t",
+			Conditions: []interface{}{expr.Equal("A", 1)},
+		})
+		require.NoError(t, err)
+		require.Equal(t, `DELETE FROM "t" WHERE "A" = ?;`, stmt.String())
+		require.Equal(t, []interface{}{int64(1)}, stmt.Args())
+	}
+}
+
+func TestInsertInto(t *testing.T) {
+	type model struct {
+		ID   string `sqlike:",primary_key"`
+		Name string
+	}
+
+	var (
+		s      = New()
+		fields = reflext.DefaultMapper.CodecByType(reflect.TypeOf(model{})).Properties()
+		v      = reflect.ValueOf([]model{{ID: "1", Name: "a"}})
+	)
+
+	{
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := s.InsertInto(stmt, "db", "t", "$Key", reflext.DefaultMapper, codec.DefaultRegistry, fields, v, options.Insert().SetMode(options.InsertIgnore))
+		require.NoError(t, err)
+		require.Equal(t, `INSERT OR IGNORE INTO "t" ("ID","Name") VALUES (?,?);`, stmt.String())
+		require.Equal(t, []interface{}{"1", "a"}, stmt.Args())
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := s.InsertInto(stmt, "db", "t", "$Key", reflext.DefaultMapper, codec.DefaultRegistry, fields, v, options.Insert().SetMode(options.InsertOnDuplicate))
+		require.NoError(t, err)
+		require.Equal(t, `INSERT INTO "t" ("ID","Name") VALUES (?,?) ON CONFLICT ("ID") DO UPDATE SET "Name"=EXCLUDED."Name";`, stmt.String())
+		require.Equal(t, []interface{}{"1", "a"}, stmt.Args())
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := s.InsertInto(stmt, "db", "t", "$Key", reflext.DefaultMapper, codec.DefaultRegistry, fields, v, options.Insert().SetOnConflict(
+			expr.ColumnValue("Name", expr.Raw(`COALESCE(EXCLUDED."Name","Name")`)),
+		))
+		require.NoError(t, err)
+		require.Equal(t, `INSERT INTO "t" ("ID","Name") VALUES (?,?) ON CONFLICT ("ID") DO UPDATE SET "Name" = COALESCE(EXCLUDED."Name","Name");`, stmt.String())
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := s.InsertInto(stmt, "db", "t", "$Key", reflext.DefaultMapper, codec.DefaultRegistry, fields, v, options.Insert().SetKeepOnConflict("Name"))
+		require.NoError(t, err)
+		require.Equal(t, `INSERT INTO "t" ("ID","Name") VALUES (?,?) ON CONFLICT ("ID") DO NOTHING;`, stmt.String())
+	}
+
+	{
+		type user struct {
+			ID        string `sqlike:",primary_key"`
+			Name      string
+			CreatedAt time.Time `sqlike:",auto_create_time"`
+			UpdatedAt time.Time `sqlike:",auto_update_time"`
+		}
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := s.InsertInto(
+			stmt, "db", "t", "$Key", reflext.DefaultMapper, codec.DefaultRegistry,
+			reflext.DefaultMapper.CodecByType(reflect.TypeOf(user{})).Properties(),
+			reflect.ValueOf([]user{{ID: "1", Name: "a"}}),
+			options.Insert().SetMode(options.InsertOnDuplicate),
+		)
+		require.NoError(t, err)
+		// the created time of the existing record shouldn't be overwritten
+		require.Equal(t, `INSERT INTO "t" ("ID","Name","CreatedAt","UpdatedAt") VALUES (?,?,?,?) ON CONFLICT ("ID") DO UPDATE SET "Name"=EXCLUDED."Name","UpdatedAt"=EXCLUDED."UpdatedAt";`, stmt.String())
+	}
+}
+
+func TestSelectWith(t *testing.T) {
+	lite := New()
+	stmt := sqlstmt.NewStatement(lite)
+	err := lite.parser.BuildStatement(stmt, sql.Select("ID").
+		With(sql.With("Active", sql.Select("ID").From("db", "User").Where(expr.Equal("Status", "ACTIVE")))).
+		From("Active"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, `WITH "Active" AS (SELECT "ID" FROM "User" WHERE "Status" = ?) SELECT "ID" FROM "Active"`, stmt.String())
+}
+
+func TestSelectWindow(t *testing.T) {
+	lite := New()
+	stmt := sqlstmt.NewStatement(lite)
+	err := lite.parser.BuildStatement(stmt, sql.Select(
+		expr.As(expr.Over(expr.FirstValue("Amount"), expr.Window().PartitionBy("Category").Range(expr.UnboundedPreceding(), expr.UnboundedFollowing())), "First"),
+		expr.As(expr.Over(expr.Sum("Amount"), expr.Window()), "Total"),
+	).From("Sales"))
+	require.NoError(t, err)
+	require.Equal(t, `SELECT (FIRST_VALUE("Amount") OVER (PARTITION BY "Category" RANGE BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)) AS "First",(SUM("Amount") OVER ()) AS "Total" FROM "Sales"`, stmt.String())
+}
@@ -0,0 +1,37 @@
+package sqlite
+
+import (
+	"github.com/RevenueMonster/sqlike/sql/codec"
+	"github.com/RevenueMonster/sqlike/sql/dialect"
+	"github.com/RevenueMonster/sqlike/sql/schema"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	sqlutil "github.com/RevenueMonster/sqlike/sql/util"
+)
+
+// SQLite :
+type SQLite struct {
+	schema *schema.Builder
+	parser *sqlstmt.StatementBuilder
+	sqlutil.SQLiteUtil
+}
+
+var _ dialect.Dialect = (*(SQLite))(nil)
+
+// New :
+func New() *SQLite {
+	sb := schema.NewBuilder()
+	pr := sqlstmt.NewStatementBuilder()
+
+	sqliteSchema{}.SetBuilders(sb)
+	sqliteBuilder{}.SetRegistryAndBuilders(codec.DefaultRegistry, pr)
+
+	return &SQLite{
+		schema: sb,
+		parser: pr,
+	}
+}
+
+// GetVersion :
+func (s SQLite) GetVersion(stmt sqlstmt.Stmt) {
+	stmt.WriteString("SELECT SQLITE_VERSION();")
+}
@@ -0,0 +1,25 @@
+package sqlite
+
+import (
+	"testing"
+
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVersion(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+	s.GetVersion(stmt)
+	require.Equal(t, "SELECT SQLITE_VERSION();", stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
+
+func TestConnect(t *testing.T) {
+	s := New()
+	require.Equal(t, "file::memory:?cache=shared", s.Connect(options.Connect()))
+	require.Equal(t, "file:test.db", s.Connect(options.Connect().SetHost("test.db")))
+	require.Equal(t, "file:test.db?_fk=1", s.Connect(options.Connect().ApplyURI("file:test.db?_fk=1")))
+}
@@ -0,0 +1,264 @@
+package sqlite
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/driver"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sql/util"
+	"github.com/RevenueMonster/sqlike/sqlike/columns"
+	"github.com/RevenueMonster/sqlike/sqlike/indexes"
+)
+
+// HasPrimaryKey :
+func (s SQLite) HasPrimaryKey(stmt sqlstmt.Stmt, db, table string) {
+	stmt.WriteString("SELECT COUNT(*) FROM pragma_table_info(?) WHERE pk > 0;")
+	stmt.AppendArgs(table)
+}
+
+// RenameTable :
+func (s SQLite) RenameTable(stmt sqlstmt.Stmt, db, oldName, newName string) {
+	stmt.WriteString("ALTER TABLE ")
+	stmt.WriteString(s.TableName(db, oldName))
+	stmt.WriteString(" RENAME TO ")
+	stmt.WriteString(s.TableName(db, newName))
+	stmt.WriteByte(';')
+}
+
+// DropTable :
+func (s SQLite) DropTable(stmt sqlstmt.Stmt, db, table string, exists bool) {
+	stmt.WriteString("DROP TABLE")
+	if exists {
+		stmt.WriteString(" IF EXISTS")
+	}
+	stmt.WriteByte(' ')
+	stmt.WriteString(s.TableName(db, table) + ";")
+}
+
+// TruncateTable : sqlite doesn't have `TRUNCATE` statement, `DELETE` without `WHERE` is optimised as truncate
+func (s SQLite) TruncateTable(stmt sqlstmt.Stmt, db, table string) {
+	stmt.WriteString("DELETE FROM " + s.TableName(db, table) + ";")
+}
+
+// HasTable :
+func (s SQLite) HasTable(stmt sqlstmt.Stmt, dbName, table string) {
+	stmt.WriteString(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?;`)
+	stmt.AppendArgs(table)
+}
+
+// CreateTable :
+func (s SQLite) CreateTable(stmt sqlstmt.Stmt, db, table, pk string, info driver.Info, fields []reflext.StructFielder) (err error) {
+	var (
+		col     columns.Column
+		pkk     reflext.StructFielder
+		uniques = make([]string, 0)
+	)
+
+	fks, err := indexes.ForeignKeys(fields)
+	if err != nil {
+		return err
+	}
+
+	for _, sf := range fields {
+		tag := sf.Tag()
+		// allow primary_key tag to override
+		if _, ok := tag.LookUp("primary_key"); ok {
+			pkk = sf
+			break
+		} else if _, ok := tag.LookUp("auto_increment"); ok {
+			pkk = sf
+		} else if sf.Name() == pk && pkk == nil {
+			pkk = sf
+		}
+	}
+
+	stmt.WriteString("CREATE TABLE " + s.TableName(db, table) + " ")
+	stmt.WriteByte('(')
+
+	// Main columns :
+	for i, sf := range fields {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+
+		col, err = s.schema.GetColumn(info, sf)
+		if err != nil {
+			return
+		}
+
+		tag := sf.Tag()
+		// auto increment only work on `INTEGER PRIMARY KEY`
+		if _, ok := tag.LookUp("auto_increment"); ok && pkk != nil && pkk.Name() == sf.Name() {
+			col.Type = affinityInteger
+			col.Extra = "PRIMARY KEY AUTOINCREMENT"
+			pkk = nil
+		}
+
+		if _, ok := tag.LookUp("unique_index"); ok {
+			uniques = append(uniques, sf.Name())
+		}
+
+		s.buildSchemaByColumn(stmt, col)
+
+		// check generated columns
+		t := reflext.Deref(sf.Type())
+		if t.Kind() != reflect.Struct {
+			continue
+		}
+
+		children := sf.Children()
+		for len(children) > 0 {
+			child := children[0]
+			if name, stored, ok := s.lookUpGeneratedColumn(child); ok {
+				stmt.WriteByte(',')
+				col, err = s.schema.GetColumn(info, child)
+				if err != nil {
+					return
+				}
+				if name == "" {
+					name = col.Name
+				}
+				col.Name = name
+				s.buildGeneratedColumn(stmt, sf, child, col, stored)
+			}
+			children = children[1:]
+			children = append(children, child.Children()...)
+		}
+
+	}
+	if pkk != nil {
+		stmt.WriteByte(',')
+		stmt.WriteString("PRIMARY KEY (" + s.Quote(pkk.Name()) + ")")
+	}
+	for _, fk := range fks {
+		stmt.WriteByte(',')
+		s.buildForeignKey(stmt, table, fk)
+	}
+	stmt.WriteByte(')')
+	stmt.WriteByte(';')
+
+	// create unique index separately, so the index name is consistent with other dialects
+	for _, name := range uniques {
+		idx := indexes.Index{Columns: indexes.Columns(name)}
+		stmt.WriteString("CREATE UNIQUE INDEX " + s.Quote(idx.GetName()) + " ON " + s.TableName(db, table) + " (" + s.Quote(name) + ");")
+	}
+	return
+}
+
+// ColumnSchema : the column definition of the struct field
+func (s *SQLite) ColumnSchema(info driver.Info, sf reflext.StructFielder) (columns.Column, error) {
+	return s.schema.GetColumn(info, sf)
+}
+
+// AlterTable : sqlite `ALTER TABLE` is very limited, it can only add and drop column, one column per statement.
+// Existing columns and primary key cannot be modified without recreating the table, so it's skipped.
+func (s *SQLite) AlterTable(stmt sqlstmt.Stmt, db, table, pk string, hasPk bool, info driver.Info, fields []reflext.StructFielder, cols util.StringSlice, idxs util.StringSlice, unsafe bool) (err error) {
+	var (
+		col columns.Column
+		idx int
+	)
+
+	alter := "ALTER TABLE " + s.TableName(db, table) + " "
+	for _, sf := range fields {
+		exists := false
+		idx = cols.IndexOf(sf.Name())
+		if idx > -1 {
+			exists = true
+			cols.Splice(idx)
+		}
+
+		if !exists {
+			col, err = s.schema.GetColumn(info, sf)
+			if err != nil {
+				return
+			}
+			// non-constant default value is not allowed when adding new column
+			if col.DefaultValue != nil && strings.Contains(*col.DefaultValue, "(") {
+				col.Nullable = true
+			}
+			stmt.WriteString(alter + "ADD COLUMN ")
+			s.buildSchemaByColumn(stmt, col)
+			stmt.WriteByte(';')
+		}
+
+		if _, ok := sf.Tag().LookUp("unique_index"); ok {
+			uidx := indexes.Index{Columns: indexes.Columns(sf.Name())}
+			if idxs.IndexOf(uidx.GetName()) < 0 {
+				stmt.WriteString("CREATE UNIQUE INDEX " + s.Quote(uidx.GetName()) + " ON " + s.TableName(db, table) + " (" + s.Quote(sf.Name()) + ");")
+			}
+		}
+
+		// check generated columns
+		t := reflext.Deref(sf.Type())
+		if t.Kind() != reflect.Struct {
+			continue
+		}
+
+		children := sf.Children()
+		for len(children) > 0 {
+			child := children[0]
+			if name, _, ok := s.lookUpGeneratedColumn(child); ok {
+				col, err = s.schema.GetColumn(info, child)
+				if err != nil {
+					return
+				}
+				if name == "" {
+					name = col.Name
+				}
+				col.Name = name
+
+				idx = cols.IndexOf(name)
+				if idx > -1 {
+					cols.Splice(idx)
+				} else {
+					// only virtual column can be added using `ALTER TABLE`
+					stmt.WriteString(alter + "ADD COLUMN ")
+					s.buildGeneratedColumn(stmt, sf, child, col, false)
+					stmt.WriteByte(';')
+				}
+			}
+			children = children[1:]
+			children = append(children, child.Children()...)
+		}
+	}
+
+	if unsafe {
+		for _, col := range cols {
+			stmt.WriteString(alter + "DROP COLUMN " + s.Quote(col) + ";")
+		}
+	}
+
+	if stmt.String() == "" {
+		stmt.WriteString(noop)
+	}
+	return
+}
+
+func (s SQLite) lookUpGeneratedColumn(sf reflext.StructFielder) (string, bool, bool) {
+	tag := sf.Tag()
+	if v, ok := tag.LookUp("virtual_column"); ok {
+		return v, false, true
+	}
+	if v, ok := tag.LookUp("stored_column"); ok {
+		return v, true, true
+	}
+	return "", false, false
+}
+
+func (s SQLite) buildGeneratedColumn(stmt sqlstmt.Stmt, parent, child reflext.StructFielder, col columns.Column, stored bool) {
+	path := strings.TrimLeft(strings.TrimPrefix(child.Name(), parent.Name()), ".")
+	stmt.WriteString(s.Quote(col.Name))
+	stmt.WriteString(" " + col.Type)
+	stmt.WriteString(" GENERATED ALWAYS AS ")
+	stmt.WriteString("(JSON_EXTRACT(" + s.Quote(parent.Name()) + "," + s.Wrap("$."+path) + "))")
+	if stored {
+		stmt.WriteString(" STORED")
+	} else {
+		stmt.WriteString(" VIRTUAL")
+	}
+	if !col.Nullable {
+		stmt.WriteString(" NOT NULL")
+	}
+}
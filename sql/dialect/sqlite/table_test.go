@@ -0,0 +1,131 @@
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/charset"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/indexes"
+	"github.com/RevenueMonster/sqlike/types"
+	"github.com/stretchr/testify/require"
+)
+
+type driverInfo struct{}
+
+func (driverInfo) DriverName() string {
+	return "sqlite3"
+}
+
+func (driverInfo) Charset() charset.Code {
+	return charset.UTF8MB4
+}
+
+func (driverInfo) Collate() string {
+	return ""
+}
+
+type tableModel struct {
+	ID      int64 `sqlike:",auto_increment"`
+	Key     *types.Key
+	Name    string `sqlike:",unique_index"`
+	Status  string `sqlike:",enum=A|B"`
+	Flag    bool
+	Amount  float64
+	Set     types.Set
+	Address struct {
+		City string `sqlike:",virtual_column=City"`
+	}
+}
+
+func TestHasPrimaryKey(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	s.HasPrimaryKey(stmt, "db", "table")
+	require.Equal(t, "SELECT COUNT(*) FROM pragma_table_info(?) WHERE pk > 0;", stmt.String())
+	require.ElementsMatch(t, []interface{}{"table"}, stmt.Args())
+}
+
+func TestDropTable(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	{
+		s.DropTable(stmt, "db", "table", true)
+		require.Equal(t, `DROP TABLE IF EXISTS "table";`, stmt.String())
+		require.ElementsMatch(t, []interface{}{}, stmt.Args())
+	}
+
+	stmt.Reset()
+
+	{
+		s.DropTable(stmt, "db", "table", false)
+		require.Equal(t, `DROP TABLE "table";`, stmt.String())
+		require.ElementsMatch(t, []interface{}{}, stmt.Args())
+	}
+}
+
+func TestTruncateTable(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	s.TruncateTable(stmt, "db", "table")
+	require.Equal(t, `DELETE FROM "table";`, stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
+
+func TestCreateTable(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	fields := reflext.DefaultMapper.CodecByType(reflect.TypeOf(tableModel{})).Properties()
+	err := s.CreateTable(stmt, "db", "table", "$Key", driverInfo{}, fields)
+	require.NoError(t, err)
+	require.Equal(t, `CREATE TABLE "table" (`+
+		`"ID" INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,`+
+		`"Key" TEXT,`+
+		`"Name" TEXT NOT NULL DEFAULT '',`+
+		`"Status" TEXT CHECK ("Status" IN ('A','B')) NOT NULL DEFAULT 'A',`+
+		`"Flag" INTEGER NOT NULL DEFAULT '0',`+
+		`"Amount" REAL NOT NULL DEFAULT '0',`+
+		`"Set" TEXT,`+
+		`"Address" TEXT NOT NULL,`+
+		`"City" TEXT GENERATED ALWAYS AS (JSON_EXTRACT("Address",'$.City')) VIRTUAL NOT NULL);`+
+		`CREATE UNIQUE INDEX "7f72d2961d585813c5f7c3f66ae7b69c" ON "table" ("Name");`, stmt.String())
+}
+
+func TestCreateTableWithForeignKey(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	type order struct {
+		ID     int64 `sqlike:",primary_key"`
+		UserID int64 `sqlike:",foreign_key=User.ID,on_delete=cascade,on_update=set_null"`
+	}
+
+	fields := reflext.DefaultMapper.CodecByType(reflect.TypeOf(order{})).Properties()
+	err := s.CreateTable(stmt, "db", "Order", "$Key", driverInfo{}, fields)
+	require.NoError(t, err)
+	require.Equal(t, `CREATE TABLE "Order" (`+
+		`"ID" INTEGER NOT NULL DEFAULT '0',`+
+		`"UserID" INTEGER NOT NULL DEFAULT '0',`+
+		`PRIMARY KEY ("ID"),`+
+		`CONSTRAINT "FK_Order_UserID" FOREIGN KEY ("UserID") REFERENCES "User" ("ID") ON DELETE CASCADE ON UPDATE SET NULL);`, stmt.String())
+
+	stmt.Reset()
+	s.GetForeignKeys(stmt, "db", "Order")
+	require.Equal(t, `SELECT 'FK_' || ? || '_' || "from", "from", "table", "to", on_delete, on_update FROM pragma_foreign_key_list(?) ORDER BY id, seq;`, stmt.String())
+	require.ElementsMatch(t, []interface{}{"Order", "Order"}, stmt.Args())
+
+	stmt.Reset()
+	s.AddForeignKeys(stmt, "db", "Order", []indexes.ForeignKey{{Columns: []string{"UserID"}, RefTable: "User", RefColumns: []string{"ID"}}})
+	s.DropForeignKeys(stmt, "db", "Order", []string{"FK_Order_UserID"})
+	require.Empty(t, stmt.String())
+}
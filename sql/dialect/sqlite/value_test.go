@@ -0,0 +1,33 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	var (
+		s   = New()
+		str string
+	)
+
+	str = s.Format(int64(-638731231286))
+	require.Equal(t, "-638731231286", str)
+
+	str = s.Format("it's")
+	require.Equal(t, `'it''s'`, str)
+
+	str = s.Format(true)
+	require.Equal(t, "1", str)
+
+	str = s.Format(false)
+	require.Equal(t, "0", str)
+
+	str = s.Format(nil)
+	require.Equal(t, "NULL", str)
+
+	str = s.Format(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.Equal(t, `'2020-01-02 03:04:05'`, str)
+}
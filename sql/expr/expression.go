@@ -151,6 +151,12 @@ func ColumnValue(field string, value interface{}) (kv primitive.KV) {
 	return
 }
 
+// Values : refer to the value which is going to be inserted, it should only use on conflict update, eg. `VALUES(column)` or `EXCLUDED.column`
+func Values(field string) (v primitive.Inserted) {
+	v.Field = field
+	return
+}
+
 // CastAs :
 func CastAs(value interface{}, datatype primitive.DataType) (cast primitive.CastAs) {
 	cast.Value = value
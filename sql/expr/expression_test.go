@@ -72,4 +72,17 @@ func TestExpression(t *testing.T) {
 		}, grp)
 	})
 
+	t.Run("Values", func(ti *testing.T) {
+		require.Equal(ti, primitive.Inserted{Field: "A"}, Values("A"))
+	})
+
+	t.Run("Add", func(ti *testing.T) {
+		require.Equal(ti, primitive.Group{
+			Values: []interface{}{
+				wrapColumn("A"),
+				Raw(" + "),
+				primitive.Inserted{Field: "A"},
+			},
+		}, Add("A", Values("A")))
+	})
 }
@@ -0,0 +1,54 @@
+package expr
+
+import (
+	"github.com/RevenueMonster/sqlike/sqlike/primitive"
+)
+
+// Join : inner join the table with `ON` conditions, or `USING` columns by passing `expr.Using`
+func Join(table interface{}, conds ...interface{}) (j primitive.Join) {
+	j = buildJoin(table, conds)
+	j.Type = primitive.InnerJoin
+	return
+}
+
+// LeftJoin :
+func LeftJoin(table interface{}, conds ...interface{}) (j primitive.Join) {
+	j = buildJoin(table, conds)
+	j.Type = primitive.LeftJoin
+	return
+}
+
+// RightJoin :
+func RightJoin(table interface{}, conds ...interface{}) (j primitive.Join) {
+	j = buildJoin(table, conds)
+	j.Type = primitive.RightJoin
+	return
+}
+
+// CrossJoin :
+func CrossJoin(table interface{}) (j primitive.Join) {
+	j = buildJoin(table, nil)
+	j.Type = primitive.CrossJoin
+	return
+}
+
+// Using :
+func Using(columns ...string) (u primitive.Using) {
+	if len(columns) < 1 {
+		panic("empty columns for using")
+	}
+	u.Columns = columns
+	return
+}
+
+func buildJoin(table interface{}, conds []interface{}) (j primitive.Join) {
+	j.Table = wrapColumn(table)
+	if len(conds) == 1 {
+		if u, ok := conds[0].(primitive.Using); ok {
+			j.Using = u.Columns
+			return
+		}
+	}
+	j.On = And(conds...)
+	return
+}
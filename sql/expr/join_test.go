@@ -0,0 +1,36 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/RevenueMonster/sqlike/sqlike/primitive"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoin(t *testing.T) {
+	t.Run("Join with ON", func(t *testing.T) {
+		j := Join("b", Equal(Column("a", "id"), Column("b", "aid")))
+		require.Equal(t, primitive.InnerJoin, j.Type)
+		require.Equal(t, wrapColumn("b"), j.Table)
+		require.Equal(t, 1, len(j.On.Values))
+		require.Nil(t, j.Using)
+	})
+
+	t.Run("LeftJoin with USING", func(t *testing.T) {
+		j := LeftJoin("b", Using("id", "code"))
+		require.Equal(t, primitive.LeftJoin, j.Type)
+		require.Equal(t, []string{"id", "code"}, j.Using)
+		require.Equal(t, 0, len(j.On.Values))
+	})
+
+	t.Run("RightJoin and CrossJoin", func(t *testing.T) {
+		require.Equal(t, primitive.RightJoin, RightJoin("b").Type)
+		require.Equal(t, primitive.CrossJoin, CrossJoin("b").Type)
+	})
+
+	t.Run("Using with empty columns", func(t *testing.T) {
+		require.Panics(t, func() {
+			Using()
+		})
+	})
+}
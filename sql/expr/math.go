@@ -20,6 +20,17 @@ func Decrement(field string, inc uint) primitive.Math {
 	}
 }
 
+// Add :
+func Add(fields ...interface{}) (grp primitive.Group) {
+	for i, f := range fields {
+		if i > 0 {
+			grp.Values = append(grp.Values, Raw(" + "))
+		}
+		grp.Values = append(grp.Values, wrapColumn(f))
+	}
+	return
+}
+
 // Multiply :
 func Multiply(fields ...interface{}) (grp primitive.Group) {
 	for i, f := range fields {
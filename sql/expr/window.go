@@ -0,0 +1,102 @@
+package expr
+
+import (
+	"strconv"
+
+	"github.com/RevenueMonster/sqlike/sqlike/primitive"
+)
+
+// RowNumber :
+func RowNumber() (f primitive.WindowFunc) {
+	f.Type = primitive.RowNumber
+	return
+}
+
+// Rank :
+func Rank() (f primitive.WindowFunc) {
+	f.Type = primitive.Rank
+	return
+}
+
+// DenseRank :
+func DenseRank() (f primitive.WindowFunc) {
+	f.Type = primitive.DenseRank
+	return
+}
+
+// Lag : value of the field from the row lagging `offset` rows behind, default value is returned when there is no such row
+func Lag(field interface{}, offset uint, defaultValue ...interface{}) (f primitive.WindowFunc) {
+	f = offsetFunc(field, offset, defaultValue)
+	f.Type = primitive.Lag
+	return
+}
+
+// Lead : value of the field from the row leading `offset` rows after, default value is returned when there is no such row
+func Lead(field interface{}, offset uint, defaultValue ...interface{}) (f primitive.WindowFunc) {
+	f = offsetFunc(field, offset, defaultValue)
+	f.Type = primitive.Lead
+	return
+}
+
+// FirstValue :
+func FirstValue(field interface{}) (f primitive.WindowFunc) {
+	f.Type = primitive.FirstValue
+	f.Args = append(f.Args, wrapColumn(field))
+	return
+}
+
+// Window : window specification, pass the name to inherit from the named window
+func Window(base ...string) *primitive.Window {
+	w := new(primitive.Window)
+	if len(base) > 0 {
+		w.Base = base[0]
+	}
+	return w
+}
+
+// Over : apply window function or aggregate function over the window
+func Over(field interface{}, window *primitive.Window) (o primitive.Over) {
+	o.Field = field
+	o.Window = window
+	return
+}
+
+// OverWindow : apply window function or aggregate function over the named window
+func OverWindow(field interface{}, name string) (o primitive.Over) {
+	o.Field = field
+	o.Window = name
+	return
+}
+
+// UnboundedPreceding :
+func UnboundedPreceding() primitive.Raw {
+	return Raw("UNBOUNDED PRECEDING")
+}
+
+// Preceding :
+func Preceding(n uint) primitive.Raw {
+	return Raw(strconv.FormatUint(uint64(n), 10) + " PRECEDING")
+}
+
+// CurrentRow :
+func CurrentRow() primitive.Raw {
+	return Raw("CURRENT ROW")
+}
+
+// Following :
+func Following(n uint) primitive.Raw {
+	return Raw(strconv.FormatUint(uint64(n), 10) + " FOLLOWING")
+}
+
+// UnboundedFollowing :
+func UnboundedFollowing() primitive.Raw {
+	return Raw("UNBOUNDED FOLLOWING")
+}
+
+func offsetFunc(field interface{}, offset uint, defaultValue []interface{}) (f primitive.WindowFunc) {
+	f.Args = append(f.Args, wrapColumn(field), Raw(strconv.FormatUint(uint64(offset), 10)))
+	if len(defaultValue) > 0 {
+		f.Args = append(f.Args, primitive.Value{Raw: defaultValue[0]})
+	}
+	return
+}
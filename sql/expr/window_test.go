@@ -0,0 +1,49 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/RevenueMonster/sqlike/sqlike/primitive"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindowFunc(t *testing.T) {
+	require.Equal(t, primitive.WindowFunc{Type: primitive.RowNumber}, RowNumber())
+	require.Equal(t, primitive.WindowFunc{Type: primitive.Rank}, Rank())
+	require.Equal(t, primitive.WindowFunc{Type: primitive.DenseRank}, DenseRank())
+	require.Equal(t, primitive.WindowFunc{
+		Type: primitive.FirstValue,
+		Args: []interface{}{wrapColumn("a")},
+	}, FirstValue("a"))
+	require.Equal(t, primitive.WindowFunc{
+		Type: primitive.Lag,
+		Args: []interface{}{wrapColumn("a"), Raw("1")},
+	}, Lag("a", 1))
+	require.Equal(t, primitive.WindowFunc{
+		Type: primitive.Lead,
+		Args: []interface{}{wrapColumn("a"), Raw("2"), primitive.Value{Raw: 0}},
+	}, Lead("a", 2, 0))
+}
+
+func TestWindow(t *testing.T) {
+	w := Window("base").
+		PartitionBy("a").
+		OrderBy(Desc("b")).
+		Rows(Preceding(2), CurrentRow())
+	require.Equal(t, &primitive.Window{
+		Base:       "base",
+		Partitions: []interface{}{"a"},
+		Sorts:      []interface{}{Desc("b")},
+		Frame: &primitive.Frame{
+			Unit:  primitive.Rows,
+			Start: Raw("2 PRECEDING"),
+			End:   Raw("CURRENT ROW"),
+		},
+	}, w)
+
+	require.Equal(t, primitive.Over{Field: RowNumber(), Window: w}, Over(RowNumber(), w))
+	require.Equal(t, primitive.Over{Field: Sum("a"), Window: "w"}, OverWindow(Sum("a"), "w"))
+	require.Equal(t, Raw("UNBOUNDED PRECEDING"), UnboundedPreceding())
+	require.Equal(t, Raw("3 FOLLOWING"), Following(3))
+	require.Equal(t, Raw("UNBOUNDED FOLLOWING"), UnboundedFollowing())
+}
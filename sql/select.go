@@ -10,6 +10,7 @@ import (
 
 // SelectStmt :
 type SelectStmt struct {
+	CTEs        []primitive.CTE
 	DistinctOn  bool
 	Tables      []interface{}
 	Projections []interface{}
@@ -18,6 +19,7 @@ type SelectStmt struct {
 	Conditions  primitive.Group
 	Havings     primitive.Group
 	Groups      []interface{}
+	Windows     []primitive.NamedWindow
 	Sorts       []interface{}
 	Max         uint
 	Skip        uint
@@ -29,6 +31,12 @@ func Select(fields ...interface{}) *SelectStmt {
 	return stmt.Select(fields...)
 }
 
+// With :
+func (stmt *SelectStmt) With(ctes ...primitive.CTE) *SelectStmt {
+	stmt.CTEs = append(stmt.CTEs, ctes...)
+	return stmt
+}
+
 // Select :
 func (stmt *SelectStmt) Select(fields ...interface{}) *SelectStmt {
 	if len(fields) == 1 {
@@ -78,6 +86,30 @@ func (stmt *SelectStmt) From(values ...interface{}) *SelectStmt {
 	return stmt
 }
 
+// Join :
+func (stmt *SelectStmt) Join(table interface{}, conds ...interface{}) *SelectStmt {
+	stmt.Joins = append(stmt.Joins, expr.Join(table, conds...))
+	return stmt
+}
+
+// LeftJoin :
+func (stmt *SelectStmt) LeftJoin(table interface{}, conds ...interface{}) *SelectStmt {
+	stmt.Joins = append(stmt.Joins, expr.LeftJoin(table, conds...))
+	return stmt
+}
+
+// RightJoin :
+func (stmt *SelectStmt) RightJoin(table interface{}, conds ...interface{}) *SelectStmt {
+	stmt.Joins = append(stmt.Joins, expr.RightJoin(table, conds...))
+	return stmt
+}
+
+// CrossJoin :
+func (stmt *SelectStmt) CrossJoin(table interface{}) *SelectStmt {
+	stmt.Joins = append(stmt.Joins, expr.CrossJoin(table))
+	return stmt
+}
+
 // Distinct :
 func (stmt *SelectStmt) Distinct() *SelectStmt {
 	stmt.DistinctOn = true
@@ -96,6 +128,12 @@ func (stmt *SelectStmt) Having(fields ...interface{}) *SelectStmt {
 	return stmt
 }
 
+// Window : declare named window which can be referenced using `expr.OverWindow`
+func (stmt *SelectStmt) Window(name string, window *primitive.Window) *SelectStmt {
+	stmt.Windows = append(stmt.Windows, primitive.NamedWindow{Name: name, Window: window})
+	return stmt
+}
+
 // OrderBy :
 func (stmt *SelectStmt) OrderBy(fields ...interface{}) *SelectStmt {
 	stmt.Sorts = fields
@@ -67,16 +67,18 @@ func (sm *Statement) Format(state fmt.State, verb rune) {
 		i    = 1
 		args = sm.Args()
 		idx  int
+		v    string
 	)
 	for {
-		idx = strings.Index(str, sm.fmt.Var(i))
+		v = sm.fmt.Var(i)
+		idx = strings.Index(str, v)
 		if idx < 0 {
 			state.Write([]byte(str))
 			break
 		}
 		state.Write([]byte(str[:idx]))
 		state.Write([]byte(sm.fmt.Format(args[0])))
-		str = str[idx+1:]
+		str = str[idx+len(v):]
 		args = args[1:]
 		i++
 	}
@@ -6,6 +6,7 @@ import (
 
 // UpdateStmt :
 type UpdateStmt struct {
+	CTEs       []primitive.CTE
 	Database   string
 	Table      string
 	Conditions primitive.Group
@@ -25,6 +26,12 @@ func (stmt *UpdateStmt) Update(fields ...interface{}) *UpdateStmt {
 	return stmt
 }
 
+// With :
+func (stmt *UpdateStmt) With(ctes ...primitive.CTE) *UpdateStmt {
+	stmt.CTEs = append(stmt.CTEs, ctes...)
+	return stmt
+}
+
 // Where :
 func (stmt *UpdateStmt) Where(fields ...interface{}) *UpdateStmt {
 	// stmt.Conditions = expr.And(fields...)
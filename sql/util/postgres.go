@@ -1,11 +1,38 @@
 package util
 
-import "strconv"
+import (
+	"strconv"
+	"strings"
+)
 
 // PostgresUtil :
 type PostgresUtil struct{}
 
+// TableName :
+func (util PostgresUtil) TableName(db, table string) string {
+	return util.Quote(db) + "." + util.Quote(table)
+}
+
+// Var :
+func (util PostgresUtil) Var(i int) string {
+	return "$" + strconv.Itoa(i)
+}
+
 // Quote :
 func (util PostgresUtil) Quote(n string) string {
 	return strconv.Quote(n)
 }
+
+// Wrap :
+func (util PostgresUtil) Wrap(n string) string {
+	return "'" + strings.ReplaceAll(n, "'", "''") + "'"
+}
+
+// WrapOnlyValue :
+func (util PostgresUtil) WrapOnlyValue(n string) string {
+	// TODO: regex to check the string with () symbols
+	if strings.Contains(n, "(") {
+		return n
+	}
+	return util.Wrap(n)
+}
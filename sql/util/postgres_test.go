@@ -10,4 +10,10 @@ func TestPostgresUtil(t *testing.T) {
 	utl := PostgresUtil{}
 
 	require.Equal(t, `"abc"`, utl.Quote("abc"))
+	require.Equal(t, `"db"."table"`, utl.TableName("db", "table"))
+	require.Equal(t, "$1", utl.Var(1))
+	require.Equal(t, "$10", utl.Var(10))
+	require.Equal(t, `'value'`, utl.Wrap("value"))
+	require.Equal(t, `'it''s'`, utl.Wrap("it's"))
+	require.Equal(t, `CURRENT_TIMESTAMP(6)`, utl.WrapOnlyValue("CURRENT_TIMESTAMP(6)"))
 }
@@ -0,0 +1,38 @@
+package util
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SQLiteUtil :
+type SQLiteUtil struct{}
+
+// TableName : sqlite database is bind to a file, so the database name is ignored
+func (util SQLiteUtil) TableName(db, table string) string {
+	return util.Quote(table)
+}
+
+// Var :
+func (util SQLiteUtil) Var(i int) string {
+	return "?"
+}
+
+// Quote :
+func (util SQLiteUtil) Quote(n string) string {
+	return strconv.Quote(n)
+}
+
+// Wrap :
+func (util SQLiteUtil) Wrap(n string) string {
+	return "'" + strings.ReplaceAll(n, "'", "''") + "'"
+}
+
+// WrapOnlyValue : sqlite requires expression of default value to be enclosed in parentheses
+func (util SQLiteUtil) WrapOnlyValue(n string) string {
+	// TODO: regex to check the string with () symbols
+	if strings.Contains(n, "(") {
+		return "(" + n + ")"
+	}
+	return util.Wrap(n)
+}
@@ -0,0 +1,19 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteUtil(t *testing.T) {
+	utl := SQLiteUtil{}
+
+	require.Equal(t, `"abc"`, utl.Quote("abc"))
+	require.Equal(t, `"table"`, utl.TableName("db", "table"))
+	require.Equal(t, "?", utl.Var(1))
+	require.Equal(t, "?", utl.Var(10))
+	require.Equal(t, `'value'`, utl.Wrap("value"))
+	require.Equal(t, `'it''s'`, utl.Wrap("it's"))
+	require.Equal(t, `(strftime('%Y-%m-%d %H:%M:%f', 'now'))`, utl.WrapOnlyValue("strftime('%Y-%m-%d %H:%M:%f', 'now')"))
+}
@@ -0,0 +1,33 @@
+package sql
+
+import (
+	"strings"
+
+	"github.com/RevenueMonster/sqlike/sqlike/primitive"
+)
+
+// With : declare a common table expression which can be referenced by name in the main statement
+func With(name string, stmt *SelectStmt) primitive.CTE {
+	return primitive.CTE{
+		Name:   mustCTEName(name),
+		Anchor: stmt,
+	}
+}
+
+// WithRecursive : declare a recursive common table expression, the recursive statement is joined with the anchor statement using `UNION ALL`
+func WithRecursive(name string, anchor *SelectStmt, recursive *SelectStmt) primitive.CTE {
+	return primitive.CTE{
+		Name:      mustCTEName(name),
+		Recursive: true,
+		Anchor:    anchor,
+		Recursion: recursive,
+	}
+}
+
+func mustCTEName(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		panic("empty common table expression name")
+	}
+	return name
+}
@@ -4,14 +4,20 @@ import (
 	"strings"
 
 	"github.com/RevenueMonster/sqlike/sql/expr"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
 	"github.com/RevenueMonster/sqlike/sqlike/primitive"
 )
 
 // SelectStatement :
 type SelectStatement interface {
+	With(ctes ...primitive.CTE) SelectStatement
 	Distinct() SelectStatement
 	Select(fields ...interface{}) SelectStatement
 	From(values ...string) SelectStatement
+	Join(table interface{}, conds ...interface{}) SelectStatement
+	LeftJoin(table interface{}, conds ...interface{}) SelectStatement
+	RightJoin(table interface{}, conds ...interface{}) SelectStatement
+	CrossJoin(table interface{}) SelectStatement
 	Where(fields ...interface{}) SelectStatement
 	Having(fields ...interface{}) SelectStatement
 	GroupBy(fields ...interface{}) SelectStatement
@@ -22,17 +28,29 @@ type SelectStatement interface {
 
 // FindActions :
 type FindActions struct {
+	CTEs        []primitive.CTE
 	DistinctOn  bool
 	Database    string
 	Table       string
 	Projections []interface{}
 	IndexHints  string
+	Joins       []interface{}
 	Conditions  primitive.Group
 	Havings     primitive.Group
 	GroupBys    []interface{}
 	Sorts       []interface{}
 	Skip        uint
 	Count       uint
+
+	// the options of locking clause, the lock mode is passed to `Dialect.Select`
+	LockOf   []string
+	LockWait options.LockWaitPolicy
+}
+
+// With :
+func (act *FindActions) With(ctes ...primitive.CTE) SelectStatement {
+	act.CTEs = append(act.CTEs, ctes...)
+	return act
 }
 
 // Select :
@@ -69,6 +87,30 @@ func (act *FindActions) From(values ...string) SelectStatement {
 	return act
 }
 
+// Join :
+func (act *FindActions) Join(table interface{}, conds ...interface{}) SelectStatement {
+	act.Joins = append(act.Joins, expr.Join(table, conds...))
+	return act
+}
+
+// LeftJoin :
+func (act *FindActions) LeftJoin(table interface{}, conds ...interface{}) SelectStatement {
+	act.Joins = append(act.Joins, expr.LeftJoin(table, conds...))
+	return act
+}
+
+// RightJoin :
+func (act *FindActions) RightJoin(table interface{}, conds ...interface{}) SelectStatement {
+	act.Joins = append(act.Joins, expr.RightJoin(table, conds...))
+	return act
+}
+
+// CrossJoin :
+func (act *FindActions) CrossJoin(table interface{}) SelectStatement {
+	act.Joins = append(act.Joins, expr.CrossJoin(table))
+	return act
+}
+
 // Where :
 func (act *FindActions) Where(fields ...interface{}) SelectStatement {
 	act.Conditions = expr.And(fields...)
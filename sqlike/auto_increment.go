@@ -0,0 +1,150 @@
+package sqlike
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	sqldialect "github.com/RevenueMonster/sqlike/sql/dialect"
+	sqldriver "github.com/RevenueMonster/sqlike/sql/driver"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/logs"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+)
+
+// returningResult : the result of insert with `RETURNING` clause
+type returningResult struct {
+	ids []int64
+}
+
+var _ sql.Result = (*returningResult)(nil)
+
+// LastInsertId : the generated value of the first inserted record
+func (r *returningResult) LastInsertId() (int64, error) {
+	if len(r.ids) == 0 {
+		return 0, errors.New("sqlike: no record inserted")
+	}
+	return r.ids[0], nil
+}
+
+// RowsAffected :
+func (r *returningResult) RowsAffected() (int64, error) {
+	return int64(len(r.ids)), nil
+}
+
+// autoIncrementOf : lookup the field with `auto_increment` tag, it will return nil if the field is omitted
+func autoIncrementOf(fields []reflext.StructFielder, opt *options.InsertOptions) reflext.StructFielder {
+	for _, sf := range fields {
+		if _, ok := sf.Tag().LookUp("auto_increment"); !ok {
+			continue
+		}
+		if opt.Mode != options.InsertOnDuplicate && opt.Omits.IndexOf(sf.Name()) > -1 {
+			return nil
+		}
+		if !isVersionKind(reflext.Deref(sf.Type()).Kind()) {
+			return nil
+		}
+		return sf
+	}
+	return nil
+}
+
+// insertReturning : execute the insert statement with `RETURNING` clause, and populate the generated values back to the records
+func insertReturning(ctx context.Context, driver sqldriver.Driver, stmt *sqlstmt.Statement, logger logs.Logger, cache reflext.StructMapper, v reflect.Value, sf reflext.StructFielder) (sql.Result, error) {
+	rows, err := sqldriver.Query(ctx, driver, stmt, logger)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := new(returningResult)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		result.ids = append(result.ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// the records might be skipped on conflict, so we can't tell which record the value belongs to
+	if len(result.ids) == v.Len() {
+		// the order of the returned rows is not guaranteed, but the generated values are increasing in the order of `VALUES`
+		ids := append([]int64(nil), result.ids...)
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		for i, id := range ids {
+			setInsertID(cache, v.Index(i), sf, id)
+		}
+	}
+	return result, nil
+}
+
+// autoIncrementStep : the interval of the generated values, it's not 1 on multi-primary cluster, eg. galera or group replication
+func autoIncrementStep(ctx context.Context, driver sqldriver.Driver) (int64, error) {
+	var step int64
+	if err := driver.QueryRowContext(ctx, "SELECT @@auto_increment_increment;").Scan(&step); err != nil {
+		return 0, err
+	}
+	if step < 1 {
+		return 0, errors.New("sqlike: invalid auto_increment_increment")
+	}
+	return step, nil
+}
+
+// assignInsertIDs : populate the generated values using `LastInsertId`, the generated values of a single statement are spaced by `auto_increment_increment`.
+// It's only applicable on plain insert where all the records don't have value, as the records might be skipped or updated on conflict.
+// The records are inserted even if it returns error, and the `auto_increment` field remains zero.
+func assignInsertIDs(ctx context.Context, driver sqldriver.Driver, cache reflext.StructMapper, v reflect.Value, sf reflext.StructFielder, result sql.Result, mode sqldialect.InsertIDMode, opt *options.InsertOptions) error {
+	if opt.Mode != 0 {
+		return nil
+	}
+	for i := 0; i < v.Len(); i++ {
+		if !reflext.IsZero(cache.FieldByIndexesReadOnly(v.Index(i), sf.Index())) {
+			return nil
+		}
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("sqlike: unable to populate the auto_increment field %q: %w", sf.Name(), err)
+	}
+	// nothing is generated
+	if id <= 0 {
+		return nil
+	}
+	step := int64(1)
+	if v.Len() > 1 && mode == sqldialect.FirstInsertID {
+		// we can't tell the generated values of the records without the interval
+		if step, err = autoIncrementStep(ctx, driver); err != nil {
+			return fmt.Errorf("sqlike: unable to populate the auto_increment field %q: %w", sf.Name(), err)
+		}
+	}
+	if mode == sqldialect.LastInsertID {
+		id = id - int64(v.Len()-1)*step
+	}
+	for i := 0; i < v.Len(); i++ {
+		setInsertID(cache, v.Index(i), sf, id+int64(i)*step)
+	}
+	return nil
+}
+
+func setInsertID(cache reflext.StructMapper, ev reflect.Value, sf reflext.StructFielder, id int64) {
+	if ev.Kind() == reflect.Ptr && ev.IsNil() {
+		return
+	}
+	if !reflext.Indirect(ev).CanSet() {
+		return
+	}
+	fv := reflext.Indirect(cache.FieldByIndexes(ev, sf.Index()))
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fv.SetInt(id)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		fv.SetUint(uint64(id))
+	}
+}
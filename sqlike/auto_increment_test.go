@@ -0,0 +1,135 @@
+package sqlike
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/codec"
+	sqldialect "github.com/RevenueMonster/sqlike/sql/dialect"
+	"github.com/RevenueMonster/sqlike/sql/dialect/mysql"
+	"github.com/RevenueMonster/sqlike/sql/dialect/sqlite"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+type autoIncrementEntity struct {
+	ID   int64 `sqlike:",auto_increment"`
+	Name string
+}
+
+func TestAutoIncrement(t *testing.T) {
+	ctx := context.Background()
+	cache := reflext.DefaultMapper
+	fields := cache.CodecByType(reflect.TypeOf(autoIncrementEntity{})).Properties()
+
+	t.Run("autoIncrementOf", func(it *testing.T) {
+		sf := autoIncrementOf(fields, options.Insert())
+		require.NotNil(it, sf)
+		require.Equal(it, "ID", sf.Name())
+
+		require.Nil(it, autoIncrementOf(fields, options.Insert().SetOmitFields("ID")))
+		require.NotNil(it, autoIncrementOf(fields, options.Insert().SetOmitFields("ID").SetMode(options.InsertOnDuplicate)))
+
+		type stringEntity struct {
+			ID string `sqlike:",auto_increment"`
+		}
+		require.Nil(it, autoIncrementOf(cache.CodecByType(reflect.TypeOf(stringEntity{})).Properties(), options.Insert()))
+	})
+
+	sf := autoIncrementOf(fields, options.Insert())
+	db, d := newTxDatabase(t)
+	d.rows["auto_increment_increment"] = []driver.Value{int64(1)}
+
+	t.Run("FirstInsertID", func(it *testing.T) {
+		ents := []autoIncrementEntity{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+		require.NoError(it, assignInsertIDs(ctx, db.driver, cache, reflect.ValueOf(ents), sf, batchResult(1), sqldialect.FirstInsertID, options.Insert()))
+		require.Equal(it, []int64{100, 101, 102}, []int64{ents[0].ID, ents[1].ID, ents[2].ID})
+	})
+
+	t.Run("FirstInsertID with auto_increment_increment", func(it *testing.T) {
+		db, d := newTxDatabase(it)
+		d.rows["auto_increment_increment"] = []driver.Value{int64(3)}
+		ents := []autoIncrementEntity{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+		require.NoError(it, assignInsertIDs(ctx, db.driver, cache, reflect.ValueOf(ents), sf, batchResult(1), sqldialect.FirstInsertID, options.Insert()))
+		require.Equal(it, []int64{100, 103, 106}, []int64{ents[0].ID, ents[1].ID, ents[2].ID})
+
+		// the generated values are unknown without the interval
+		db, d = newTxDatabase(it)
+		d.errs["auto_increment_increment"] = errors.New("access denied")
+		ents = []autoIncrementEntity{{Name: "a"}, {Name: "b"}}
+		err := assignInsertIDs(ctx, db.driver, cache, reflect.ValueOf(ents), sf, batchResult(1), sqldialect.FirstInsertID, options.Insert())
+		require.EqualError(it, err, `sqlike: unable to populate the auto_increment field "ID": access denied`)
+		require.Equal(it, []int64{0, 0}, []int64{ents[0].ID, ents[1].ID})
+
+		// single record doesn't need the interval
+		ents = []autoIncrementEntity{{Name: "a"}}
+		require.NoError(it, assignInsertIDs(ctx, db.driver, cache, reflect.ValueOf(ents), sf, batchResult(1), sqldialect.FirstInsertID, options.Insert()))
+		require.Equal(it, int64(100), ents[0].ID)
+	})
+
+	t.Run("LastInsertId failed", func(it *testing.T) {
+		ents := []autoIncrementEntity{{Name: "a"}}
+		result := &BatchResult{lastInsertErr: errors.New("not supported")}
+		err := assignInsertIDs(ctx, db.driver, cache, reflect.ValueOf(ents), sf, result, sqldialect.FirstInsertID, options.Insert())
+		require.EqualError(it, err, `sqlike: unable to populate the auto_increment field "ID": not supported`)
+		require.Equal(it, int64(0), ents[0].ID)
+	})
+
+	t.Run("LastInsertID", func(it *testing.T) {
+		ents := []*autoIncrementEntity{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+		require.NoError(it, assignInsertIDs(ctx, db.driver, cache, reflect.ValueOf(ents), sf, batchResult(1), sqldialect.LastInsertID, options.Insert()))
+		require.Equal(it, []int64{98, 99, 100}, []int64{ents[0].ID, ents[1].ID, ents[2].ID})
+	})
+
+	t.Run("skip when some of the records have value", func(it *testing.T) {
+		ents := []autoIncrementEntity{{Name: "a"}, {ID: 10, Name: "b"}}
+		require.NoError(it, assignInsertIDs(ctx, db.driver, cache, reflect.ValueOf(ents), sf, batchResult(1), sqldialect.FirstInsertID, options.Insert()))
+		require.Equal(it, int64(0), ents[0].ID)
+		require.Equal(it, int64(10), ents[1].ID)
+	})
+
+	t.Run("skip on conflict", func(it *testing.T) {
+		ents := []autoIncrementEntity{{Name: "a"}}
+		require.NoError(it, assignInsertIDs(ctx, db.driver, cache, reflect.ValueOf(ents), sf, batchResult(1), sqldialect.FirstInsertID, options.Insert().SetMode(options.InsertIgnore)))
+		require.Equal(it, int64(0), ents[0].ID)
+	})
+
+	t.Run("Returning", func(it *testing.T) {
+		db, d := newTxDatabase(it)
+		// the returned rows are not in the order of `VALUES`
+		d.rows["RETURNING"] = []driver.Value{int64(12), int64(10), int64(11)}
+		ents := []autoIncrementEntity{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+		stmt := sqlstmt.AcquireStmt(sqlite.New())
+		defer sqlstmt.ReleaseStmt(stmt)
+		require.NoError(it, sqlite.New().InsertInto(stmt, "db", "User", "$Key", cache, codec.DefaultRegistry, fields, reflect.ValueOf(ents), options.Insert()))
+		require.Equal(it, `INSERT INTO "User" ("ID","Name") VALUES (?,?),(?,?),(?,?) RETURNING "ID";`, stmt.String())
+
+		result, err := insertReturning(ctx, db.driver, stmt, nil, cache, reflect.ValueOf(ents), sf)
+		require.NoError(it, err)
+		require.Equal(it, []int64{10, 11, 12}, []int64{ents[0].ID, ents[1].ID, ents[2].ID})
+		affected, err := result.RowsAffected()
+		require.NoError(it, err)
+		require.Equal(it, int64(3), affected)
+	})
+
+	t.Run("InsertOne", func(it *testing.T) {
+		tb := &Table{
+			dbName:  "db",
+			name:    "User",
+			pk:      "$Key",
+			client:  &Client{cache: cache},
+			driver:  new(batchDriver),
+			dialect: mysql.New(),
+			codec:   codec.DefaultRegistry,
+		}
+		ent := autoIncrementEntity{Name: "a"}
+		_, err := tb.InsertOne(ctx, &ent)
+		require.NoError(it, err)
+		require.Equal(it, int64(100), ent.ID)
+	})
+}
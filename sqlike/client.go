@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"strings"
+	"sync"
+	"time"
 
 	semver "github.com/Masterminds/semver/v3"
 	"github.com/RevenueMonster/sqlike/reflext"
@@ -54,6 +56,15 @@ type Client struct {
 	cache   reflext.StructMapper
 	codec   codec.Codecer
 	dialect dialect.Dialect
+
+	// clock of `auto_create_time`, `auto_update_time` and `soft_delete` columns
+	clock func() time.Time
+
+	// soft delete column of the registered tables
+	softDeletes sync.Map
+
+	// secret key to sign the cursor of `Paginator`
+	cursorKey []byte
 }
 
 // newClient : create a new client struct by providing driver, *sql.DB, dialect etc
@@ -89,6 +100,26 @@ func (c *Client) SetPrimaryKey(pk string) *Client {
 	return c
 }
 
+// SetClock : set the clock which provides the current time of `auto_create_time`, `auto_update_time` and `soft_delete` columns,
+// it's useful for testing. The default clock is `time.Now` in UTC.
+func (c *Client) SetClock(clock func() time.Time) *Client {
+	if clock == nil {
+		panic("clock cannot be nil")
+	}
+	c.clock = clock
+	return c
+}
+
+// SetCursorKey : set the secret key to sign the cursor of `Paginator`, the cursor can only be verified using the same key.
+// It will panic if the key is empty.
+func (c *Client) SetCursorKey(key []byte) *Client {
+	if len(key) == 0 {
+		panic("cursor key cannot be empty")
+	}
+	c.cursorKey = append([]byte(nil), key...)
+	return c
+}
+
 // SetCodec : Codec is a component which handling the :
 // 1. encoding between input data and driver.Valuer
 // 2. decoding between output data and sql.Scanner
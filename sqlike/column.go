@@ -5,6 +5,7 @@ import (
 
 	sqldriver "github.com/RevenueMonster/sqlike/sql/driver"
 	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/columns"
 	"github.com/RevenueMonster/sqlike/types"
 )
 
@@ -41,6 +42,21 @@ type Column struct {
 	Extra string
 }
 
+// definition : the column definition which is used to restore the column
+func (col Column) definition() columns.Column {
+	return columns.Column{
+		Name:         col.Name,
+		DataType:     col.DataType,
+		Type:         col.Type,
+		Nullable:     bool(col.IsNullable),
+		DefaultValue: col.DefaultValue,
+		Charset:      col.Charset,
+		Collation:    col.Collation,
+		Extra:        col.Extra,
+		Comment:      col.Comment,
+	}
+}
+
 // ColumnView :
 type ColumnView struct {
 	tb *Table
@@ -0,0 +1,210 @@
+package sqlike
+
+import (
+	"strconv"
+	"strings"
+)
+
+// typeAliases : the data type returned by the database may be different from the data type of the model,
+// eg. mysql returns `double` for `REAL`, postgres returns `character varying` for `VARCHAR`
+var typeAliases = map[string]map[string]string{
+	"mysql": {
+		"integer":          "int",
+		"real":             "double",
+		"double precision": "double",
+		"bool":             "tinyint",
+		"boolean":          "tinyint",
+		"dec":              "decimal",
+		"numeric":          "decimal",
+		"fixed":            "decimal",
+	},
+	"postgres": {
+		"character varying":           "varchar",
+		"character":                   "char",
+		"int2":                        "smallint",
+		"int4":                        "int",
+		"integer":                     "int",
+		"int8":                        "bigint",
+		"real":                        "float",
+		"float4":                      "float",
+		"double precision":            "double",
+		"float8":                      "double",
+		"bool":                        "boolean",
+		"numeric":                     "decimal",
+		"time without time zone":      "time",
+		"timestamp without time zone": "timestamp",
+		"time with time zone":         "timetz",
+		"timestamp with time zone":    "timestamptz",
+	},
+}
+
+// the order of the data types in the same family, the larger one is able to store the smaller one
+var typeRanks = map[string]int{
+	"tinyint":   1,
+	"smallint":  2,
+	"mediumint": 3,
+	"int":       4,
+	"bigint":    5,
+	"float":     1,
+	"double":    2,
+}
+
+// the maximum length of the string and binary types without length
+var typeSizes = map[string]int64{
+	"tinytext":   1<<8 - 1,
+	"text":       1<<16 - 1,
+	"mediumtext": 1<<24 - 1,
+	"longtext":   1<<32 - 1,
+	"tinyblob":   1<<8 - 1,
+	"blob":       1<<16 - 1,
+	"mediumblob": 1<<24 - 1,
+	"longblob":   1<<32 - 1,
+}
+
+var typeFamilies = map[string]string{
+	"tinyint":    "integer",
+	"smallint":   "integer",
+	"mediumint":  "integer",
+	"int":        "integer",
+	"bigint":     "integer",
+	"float":      "float",
+	"double":     "float",
+	"char":       "string",
+	"varchar":    "string",
+	"tinytext":   "string",
+	"text":       "string",
+	"mediumtext": "string",
+	"longtext":   "string",
+	"binary":     "binary",
+	"varbinary":  "binary",
+	"tinyblob":   "binary",
+	"blob":       "binary",
+	"mediumblob": "binary",
+	"longblob":   "binary",
+	"decimal":    "decimal",
+}
+
+type columnType struct {
+	name     string
+	args     []string
+	unsigned bool
+}
+
+// parseColumnType : normalize the data type of the dialect, the `CHECK` constraint and the display width of integer are ignored
+func parseColumnType(driver, t string) columnType {
+	t = strings.ToLower(strings.Join(strings.Fields(t), " "))
+	if i := strings.Index(t, " check "); i > -1 {
+		t = t[:i]
+	}
+
+	ct := columnType{}
+	if strings.HasSuffix(t, " zerofill") {
+		t = strings.TrimSuffix(t, " zerofill")
+	}
+	if strings.HasSuffix(t, " unsigned") {
+		t = strings.TrimSuffix(t, " unsigned")
+		ct.unsigned = true
+	}
+	if i := strings.IndexByte(t, '('); i > -1 && strings.HasSuffix(t, ")") {
+		for _, arg := range strings.Split(t[i+1:len(t)-1], ",") {
+			ct.args = append(ct.args, strings.Trim(strings.TrimSpace(arg), "'\"`"))
+		}
+		// eg. `timestamp(6) without time zone`
+		t = strings.TrimSpace(t[:i])
+	} else if i > -1 {
+		if j := strings.IndexByte(t[i:], ')'); j > -1 {
+			for _, arg := range strings.Split(t[i+1:i+j], ",") {
+				ct.args = append(ct.args, strings.TrimSpace(arg))
+			}
+			t = strings.TrimSpace(t[:i] + t[i+j+1:])
+		}
+	}
+	ct.name = t
+
+	if alias, ok := typeAliases[driver][ct.name]; ok {
+		ct.name = alias
+	}
+	if typeFamilies[ct.name] == "integer" {
+		ct.args = nil
+	}
+	return ct
+}
+
+func (ct columnType) length() (int64, bool) {
+	if size, ok := typeSizes[ct.name]; ok {
+		return size, true
+	}
+	if len(ct.args) > 0 {
+		n, err := strconv.ParseInt(ct.args[0], 10, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// sameType : compare the normalized data type, the arguments are only compared when both of them have it,
+// as postgres doesn't return the precision of the time types
+func sameType(driver, a, b string) bool {
+	x, y := parseColumnType(driver, a), parseColumnType(driver, b)
+	// postgres doesn't describe the enum, spatial and array types in `information_schema`
+	if x.name == "user-defined" || x.name == "array" || y.name == "user-defined" || y.name == "array" {
+		return true
+	}
+	if x.name != y.name || x.unsigned != y.unsigned {
+		return false
+	}
+	if len(x.args) == 0 || len(y.args) == 0 {
+		return true
+	}
+	return strings.Join(x.args, ",") == strings.Join(y.args, ",")
+}
+
+// isNarrowing : whether the values of the old data type might not fit into the new data type,
+// changing to a different family of data type is always consider as narrowing
+func isNarrowing(driver, oldType, newType string) bool {
+	x, y := parseColumnType(driver, oldType), parseColumnType(driver, newType)
+	if sameType(driver, oldType, newType) {
+		return false
+	}
+	family := typeFamilies[x.name]
+	if family == "" || family != typeFamilies[y.name] {
+		// eg. the fractional seconds precision of `DATETIME`
+		if x.name == y.name {
+			n1, ok1 := x.length()
+			n2, ok2 := y.length()
+			return !ok1 || !ok2 || n2 < n1
+		}
+		return true
+	}
+
+	switch family {
+	case "integer":
+		if x.unsigned == y.unsigned {
+			return typeRanks[y.name] < typeRanks[x.name]
+		}
+		// only the larger signed integer is able to store the unsigned integer
+		return y.unsigned || typeRanks[y.name] <= typeRanks[x.name]
+	case "float":
+		return typeRanks[y.name] < typeRanks[x.name]
+	case "string", "binary":
+		n1, ok1 := x.length()
+		n2, ok2 := y.length()
+		return !ok1 || !ok2 || n2 < n1
+	case "decimal":
+		p1, s1 := decimalArgs(x.args)
+		p2, s2 := decimalArgs(y.args)
+		return s2 < s1 || p2-s2 < p1-s1
+	}
+	return true
+}
+
+// decimalArgs : the default precision is 10 and the default scale is 0
+func decimalArgs(args []string) (int, int) {
+	precision, scale := 10, 0
+	if len(args) > 0 {
+		precision, _ = strconv.Atoi(args[0])
+	}
+	if len(args) > 1 {
+		scale, _ = strconv.Atoi(args[1])
+	}
+	return precision, scale
+}
@@ -14,4 +14,5 @@ type Column struct {
 	Charset      *string
 	Collation    *string
 	Extra        string
+	Comment      string
 }
@@ -9,11 +9,17 @@ import (
 	"github.com/RevenueMonster/sqlike/sql/dialect"
 	sqldialect "github.com/RevenueMonster/sqlike/sql/dialect"
 	"github.com/RevenueMonster/sqlike/sql/dialect/mysql"
+	"github.com/RevenueMonster/sqlike/sql/dialect/postgres"
+	"github.com/RevenueMonster/sqlike/sql/dialect/sqlite"
 	"github.com/RevenueMonster/sqlike/sqlike/options"
 )
 
 func init() {
 	dialect.RegisterDialect("mysql", mysql.New())
+	dialect.RegisterDialect("postgres", postgres.New())
+	// `mattn/go-sqlite3` is register as `sqlite3` while `modernc.org/sqlite` is register as `sqlite`
+	dialect.RegisterDialect("sqlite", sqlite.New())
+	dialect.RegisterDialect("sqlite3", sqlite.New())
 }
 
 // Open : open connection to sql server with connection string
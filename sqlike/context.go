@@ -8,12 +8,14 @@ import (
 
 const contextResolutionKey = "_sqlike_context_query"
 
+// InjectResolution : inject the scopes into the context, it will be applied on `Find`, `Paginate`, `Update`, `Delete`, `DestroyOne` and `ModifyOne` unless `NoResolution` is set
 func (tb *Database) InjectResolution(ctx context.Context, queries ...primitive.Group) context.Context {
 	query := extractResolution(ctx)
 	query = append(query, queries...)
 	return context.WithValue(ctx, contextResolutionKey, query)
 }
 
+// InjectResolution : inject the scopes into the context, it will be applied on `Find`, `Paginate`, `Update`, `Delete`, `DestroyOne` and `ModifyOne` unless `NoResolution` is set
 func (tb *Table) InjectResolution(ctx context.Context, queries ...primitive.Group) context.Context {
 	query := extractResolution(ctx)
 	query = append(query, queries...)
@@ -28,3 +30,25 @@ func extractResolution(ctx context.Context) []primitive.Group {
 	}
 	return []primitive.Group{}
 }
+
+// withResolution : append the resolution scopes injected into the context to the conditions using `AND`,
+// it will return the conditions as it is if there is no resolution.
+func withResolution(ctx context.Context, conds []interface{}) []interface{} {
+	groups := extractResolution(ctx)
+	if len(groups) == 0 {
+		return conds
+	}
+
+	// prevent to modify the underlying array of the conditions
+	conds = conds[:len(conds):len(conds)]
+	for _, group := range groups {
+		if len(group.Values) == 0 {
+			continue
+		}
+		if len(conds) > 0 {
+			conds = append(conds, primitive.And)
+		}
+		conds = append(conds, group.Values...)
+	}
+	return conds
+}
@@ -0,0 +1,47 @@
+package sqlike
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RevenueMonster/sqlike/sql/expr"
+	"github.com/RevenueMonster/sqlike/sqlike/primitive"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithResolution(t *testing.T) {
+	ctx := context.Background()
+	tb := &Table{}
+
+	conds := expr.And(expr.Equal("Status", "ACTIVE")).Values
+	require.Equal(t, conds, withResolution(ctx, conds))
+
+	ctx = tb.InjectResolution(ctx, expr.And(expr.Equal("TenantID", 1)))
+	ctx = (&Database{}).InjectResolution(ctx, primitive.Group{}, expr.Or(expr.Equal("Region", "MY"), expr.Equal("Region", "SG")))
+
+	t.Run("Without Conditions", func(it *testing.T) {
+		require.Equal(it, []interface{}{
+			expr.Equal("TenantID", 1),
+			primitive.And,
+			expr.Raw("("),
+			expr.Equal("Region", "MY"),
+			primitive.Or,
+			expr.Equal("Region", "SG"),
+			expr.Raw(")"),
+		}, withResolution(ctx, nil))
+	})
+
+	t.Run("With Conditions", func(it *testing.T) {
+		conds := make([]interface{}, 1, 10)
+		conds[0] = expr.Equal("Status", "ACTIVE")
+
+		values := withResolution(ctx, conds)
+		require.Len(it, values, 9)
+		require.Equal(it, expr.Equal("Status", "ACTIVE"), values[0])
+		require.Equal(it, primitive.And, values[1])
+		require.Equal(it, expr.Equal("TenantID", 1), values[2])
+
+		// the underlying array of the conditions shouldn't be modified
+		require.Nil(it, conds[:2][1])
+	})
+}
@@ -0,0 +1,229 @@
+package sqlike
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+	"github.com/RevenueMonster/sqlike/sqlike/primitive"
+)
+
+// ErrMissingCursorKey : the cursor key is required to sign and verify the cursor
+var ErrMissingCursorKey = errors.New("sqlike: missing cursor key, set it using `Client.SetCursorKey`")
+
+// Cursor : the opaque cursor of `Paginator`, it's a signed base64 token which embeds the values of the sort fields
+// and the hash of the query, so it's safe to be handed to the api clients.
+type Cursor string
+
+type cursorValue struct {
+	Type  string `json:"t"`
+	Value string `json:"v,omitempty"`
+}
+
+type cursorPayload struct {
+	Query  string        `json:"q"`
+	Values []cursorValue `json:"v"`
+	// the record of the cursor is excluded from the page, eg. the cursors of `PageInfo`
+	Exclusive bool `json:"x,omitempty"`
+}
+
+// CursorOf : create the cursor using the sort fields of the record, the next page will start from the record
+// when the cursor is passed to `NextCursor`, and the previous page will end at the record when it's passed to `PrevCursor`.
+func (pg *Paginator) CursorOf(record interface{}) (Cursor, error) {
+	return pg.cursorOf(record, false)
+}
+
+func (pg *Paginator) cursorOf(record interface{}, exclusive bool) (Cursor, error) {
+	values, err := pg.valuesOf(record)
+	if err != nil {
+		return "", err
+	}
+	return pg.signCursor(values, exclusive)
+}
+
+// valuesOf : the encoded values of the sort fields of the record
+func (pg *Paginator) valuesOf(record interface{}) ([]interface{}, error) {
+	v := reflext.ValueOf(record)
+	if !v.IsValid() {
+		return nil, ErrInvalidInput
+	}
+	v = reflext.Indirect(v)
+	if !reflext.IsKind(v.Type(), reflect.Struct) {
+		return nil, errors.New("sqlike: cursor only support struct")
+	}
+
+	cache := pg.table.client.cache
+	def := cache.CodecByType(v.Type())
+	values := make([]interface{}, len(pg.fields))
+	for i, f := range pg.fields {
+		name := sortFieldName(f)
+		sf, ok := def.LookUpFieldByName(name)
+		if !ok {
+			return nil, fmt.Errorf("sqlike: missing sort field %q in %v", name, v.Type())
+		}
+		val, err := encodeField(cache, pg.table.codec, v, sf)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = val
+	}
+	return values, nil
+}
+
+func (pg *Paginator) signCursor(values []interface{}, exclusive bool) (Cursor, error) {
+	key, err := pg.cursorKey()
+	if err != nil {
+		return "", err
+	}
+	hash, err := pg.queryHash()
+	if err != nil {
+		return "", err
+	}
+	payload := cursorPayload{Query: hash, Values: make([]cursorValue, len(values)), Exclusive: exclusive}
+	for i, val := range values {
+		payload.Values[i], err = marshalCursorValue(val)
+		if err != nil {
+			return "", err
+		}
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(b)
+	return Cursor(base64.RawURLEncoding.EncodeToString(mac.Sum(b))), nil
+}
+
+// parseCursor : verify the signature and the query of the cursor, and return the values of the sort fields
+func (pg *Paginator) parseCursor(cursor Cursor) (*cursorPayload, []interface{}, error) {
+	key, err := pg.cursorKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := base64.RawURLEncoding.DecodeString(string(cursor))
+	if err != nil || len(b) <= sha256.Size {
+		return nil, nil, ErrInvalidCursor
+	}
+	b, sig := b[:len(b)-sha256.Size], b[len(b)-sha256.Size:]
+	mac := hmac.New(sha256.New, key)
+	mac.Write(b)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return nil, nil, ErrInvalidCursor
+	}
+
+	payload := new(cursorPayload)
+	if err := json.Unmarshal(b, payload); err != nil {
+		return nil, nil, ErrInvalidCursor
+	}
+	hash, err := pg.queryHash()
+	if err != nil {
+		return nil, nil, err
+	}
+	// the cursor is issued by another query
+	if payload.Query != hash || len(payload.Values) != len(pg.fields) {
+		return nil, nil, ErrInvalidCursor
+	}
+	values := make([]interface{}, len(payload.Values))
+	for i, cv := range payload.Values {
+		values[i], err = unmarshalCursorValue(cv)
+		if err != nil {
+			return nil, nil, ErrInvalidCursor
+		}
+	}
+	return payload, values, nil
+}
+
+func (pg *Paginator) cursorKey() ([]byte, error) {
+	if pg.table.client == nil || len(pg.table.client.cursorKey) == 0 {
+		return nil, ErrMissingCursorKey
+	}
+	return pg.table.client.cursorKey, nil
+}
+
+// queryHash : the hash of the select statement without limit and offset, so the page size can be changed
+func (pg *Paginator) queryHash() (string, error) {
+	act := pg.action
+	act.Skip, act.Count = 0, 0
+	if act.Database == "" {
+		act.Database = pg.table.dbName
+	}
+
+	stmt := sqlstmt.AcquireStmt(pg.table.dialect)
+	defer sqlstmt.ReleaseStmt(stmt)
+	if err := pg.table.dialect.Select(stmt, &act, options.NoLock); err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write([]byte(stmt.String()))
+	fmt.Fprintf(h, "%v", stmt.Args())
+	return hex.EncodeToString(h.Sum(nil)[:16]), nil
+}
+
+func sortFieldName(f interface{}) string {
+	switch vi := f.(type) {
+	case primitive.Column:
+		return vi.Name
+	case string:
+		return vi
+	default:
+		return fmt.Sprintf("%v", vi)
+	}
+}
+
+// marshalCursorValue : the type of the value is kept, as the number will be converted to float64 by json
+func marshalCursorValue(v interface{}) (cursorValue, error) {
+	switch vi := v.(type) {
+	case nil:
+		return cursorValue{Type: "n"}, nil
+	case bool:
+		return cursorValue{Type: "b", Value: strconv.FormatBool(vi)}, nil
+	case int64:
+		return cursorValue{Type: "i", Value: strconv.FormatInt(vi, 10)}, nil
+	case uint64:
+		return cursorValue{Type: "u", Value: strconv.FormatUint(vi, 10)}, nil
+	case float64:
+		return cursorValue{Type: "f", Value: strconv.FormatFloat(vi, 'g', -1, 64)}, nil
+	case string:
+		return cursorValue{Type: "s", Value: vi}, nil
+	case []byte:
+		return cursorValue{Type: "x", Value: base64.StdEncoding.EncodeToString(vi)}, nil
+	case time.Time:
+		return cursorValue{Type: "t", Value: vi.Format(time.RFC3339Nano)}, nil
+	default:
+		return cursorValue{}, fmt.Errorf("sqlike: unsupported cursor value type %T", v)
+	}
+}
+
+func unmarshalCursorValue(cv cursorValue) (interface{}, error) {
+	switch cv.Type {
+	case "n":
+		return nil, nil
+	case "b":
+		return strconv.ParseBool(cv.Value)
+	case "i":
+		return strconv.ParseInt(cv.Value, 10, 64)
+	case "u":
+		return strconv.ParseUint(cv.Value, 10, 64)
+	case "f":
+		return strconv.ParseFloat(cv.Value, 64)
+	case "s":
+		return cv.Value, nil
+	case "x":
+		return base64.StdEncoding.DecodeString(cv.Value)
+	case "t":
+		return time.Parse(time.RFC3339Nano, cv.Value)
+	default:
+		return nil, fmt.Errorf("sqlike: unknown cursor value type %q", cv.Type)
+	}
+}
@@ -0,0 +1,116 @@
+package sqlike
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/codec"
+	"github.com/RevenueMonster/sqlike/sql/dialect/mysql"
+	"github.com/RevenueMonster/sqlike/sql/expr"
+	"github.com/RevenueMonster/sqlike/sqlike/actions"
+	"github.com/stretchr/testify/require"
+)
+
+type cursorEntity struct {
+	ID        int64
+	Name      string
+	Age       uint8
+	Score     float64
+	CreatedAt time.Time
+}
+
+func TestCursor(t *testing.T) {
+	ctx := context.Background()
+	newTable := func(key string) *Table {
+		client := &Client{cache: reflext.DefaultMapper}
+		if key != "" {
+			client.SetCursorKey([]byte(key))
+		}
+		return &Table{
+			dbName:  "db",
+			name:    "User",
+			pk:      "ID",
+			client:  client,
+			driver:  new(execDriver),
+			dialect: mysql.New(),
+			codec:   codec.DefaultRegistry,
+		}
+	}
+	paginate := func(tb *Table, age int) *Paginator {
+		pg, err := tb.Paginate(
+			ctx,
+			actions.Paginate().
+				Where(expr.GreaterOrEqual("Age", age)).
+				OrderBy(expr.Desc("Name"), expr.Asc("Score"), expr.Desc("CreatedAt")).
+				Limit(10),
+		)
+		require.NoError(t, err)
+		return pg
+	}
+
+	ent := cursorEntity{
+		ID:        100,
+		Name:      "John",
+		Age:       18,
+		Score:     88.5,
+		CreatedAt: time.Date(2021, 5, 1, 10, 30, 15, 123456789, time.UTC),
+	}
+
+	t.Run("CursorOf & NextCursor", func(it *testing.T) {
+		pg := paginate(newTable("secret"), 10)
+		cursor, err := pg.CursorOf(&ent)
+		require.NoError(it, err)
+		require.NotEmpty(it, cursor)
+
+		// the page size can be changed
+		next := paginate(newTable("secret"), 10)
+		next.action.Count = 20
+		require.NoError(it, next.NextCursor(ctx, cursor))
+		require.Equal(it, []interface{}{"John", float64(88.5), ent.CreatedAt, int64(100)}, next.values)
+		require.Len(it, next.buildAction().Conditions.Values, 3)
+	})
+
+	t.Run("tampered cursor", func(it *testing.T) {
+		pg := paginate(newTable("secret"), 10)
+		cursor, err := pg.CursorOf(ent)
+		require.NoError(it, err)
+
+		b := []byte(cursor)
+		if b[10] == 'A' {
+			b[10] = 'B'
+		} else {
+			b[10] = 'A'
+		}
+		require.Equal(it, ErrInvalidCursor, pg.NextCursor(ctx, Cursor(b)))
+		require.Equal(it, ErrInvalidCursor, pg.NextCursor(ctx, Cursor("%%%")))
+		require.Equal(it, ErrInvalidCursor, pg.NextCursor(ctx, Cursor("")))
+	})
+
+	t.Run("cursor of another query", func(it *testing.T) {
+		cursor, err := paginate(newTable("secret"), 10).CursorOf(&ent)
+		require.NoError(it, err)
+		require.Equal(it, ErrInvalidCursor, paginate(newTable("secret"), 20).NextCursor(ctx, cursor))
+		require.Equal(it, ErrInvalidCursor, paginate(newTable("another"), 10).NextCursor(ctx, cursor))
+	})
+
+	t.Run("missing cursor key", func(it *testing.T) {
+		pg := paginate(newTable(""), 10)
+		_, err := pg.CursorOf(&ent)
+		require.Equal(it, ErrMissingCursorKey, err)
+		require.Equal(it, ErrMissingCursorKey, pg.NextCursor(ctx, Cursor("abc")))
+	})
+
+	t.Run("cursor value", func(it *testing.T) {
+		for _, v := range []interface{}{nil, true, int64(-10), uint64(10), float64(1.25), "abc", []byte("abc"), ent.CreatedAt} {
+			cv, err := marshalCursorValue(v)
+			require.NoError(it, err)
+			x, err := unmarshalCursorValue(cv)
+			require.NoError(it, err)
+			require.Equal(it, v, x)
+		}
+		_, err := marshalCursorValue(struct{}{})
+		require.Error(it, err)
+	})
+}
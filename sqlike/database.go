@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"strings"
@@ -56,6 +58,7 @@ func (db *Database) Table(name string) *Table {
 
 func (db *Database) QueryRow(ctx context.Context, query string, args ...interface{}) SingleResult {
 	rslt := new(Result)
+	rslt.ctx = ctx
 	rslt.cache = db.client.cache
 	rslt.codec = db.codec
 	rows, err := db.driver.QueryContext(ctx, query, args...)
@@ -101,6 +104,7 @@ func (db *Database) QueryStmt(ctx context.Context, query interface{}) (*Result,
 	}
 
 	rslt := new(Result)
+	rslt.ctx = ctx
 	rslt.cache = db.client.cache
 	rslt.codec = db.codec
 	rslt.rows = rows
@@ -128,24 +132,63 @@ func (db *Database) beginTrans(ctx context.Context, opt *sql.TxOptions) (*Transa
 	if err != nil {
 		return nil, err
 	}
-	return &Transaction{
-		Context: ctx,
-		dbName:  db.name,
-		pk:      db.pk,
-		client:  db.client,
-		driver:  tx,
-		dialect: db.dialect,
-		logger:  db.logger,
-		codec:   db.codec,
-	}, nil
+	trans := &Transaction{
+		dbName:     db.name,
+		pk:         db.pk,
+		client:     db.client,
+		driver:     tx,
+		dialect:    db.dialect,
+		logger:     db.logger,
+		codec:      db.codec,
+		savepoints: new(int),
+	}
+	trans.Context = context.WithValue(ctx, contextTransactionKey, trans)
+	return trans, nil
 }
 
-// RunInTransaction :
+// RunInTransaction : run the callback in a transaction, the transaction is committed if the callback succeeded.
+// The whole transaction is run again on retryable error when `SetRetry` is set, so the callback should be idempotent.
+// If the context is a `SessionContext` (or derived from it) of the same client, the callback is run within a savepoint
+// of the opened transaction instead, and the options are ignored.
 func (db *Database) RunInTransaction(ctx context.Context, cb txCallback, opts ...*options.TransactionOptions) error {
+	if tx := transactionOf(ctx); tx != nil && tx.client == db.client {
+		nested := *tx
+		nested.Context = ctx
+		nested.dbName = db.name
+		return nested.RunInTransaction(cb)
+	}
 	opt := new(options.TransactionOptions)
 	if len(opts) > 0 && opts[0] != nil {
 		opt = opts[0]
 	}
+	if opt.Retry == nil {
+		return db.runInTransaction(ctx, cb, opt)
+	}
+	maxAttempts := opt.Retry.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = options.DefaultRetryAttempts
+	}
+	for attempt := 1; ; attempt++ {
+		err := db.runInTransaction(ctx, cb, opt)
+		if err == nil || attempt >= maxAttempts || !db.IsRetryableError(err) {
+			return err
+		}
+		timer := time.NewTimer(retryDelay(opt.Retry, attempt, rand.Float64()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// IsRetryableError : determine whether the transaction can be retried by the error, eg. deadlock and lock wait timeout
+func (db *Database) IsRetryableError(err error) bool {
+	return db.dialect.IsRetryableError(err)
+}
+
+func (db *Database) runInTransaction(ctx context.Context, cb txCallback, opt *options.TransactionOptions) error {
 	duration := 60 * time.Second
 	if opt.Duration.Seconds() > 0 {
 		duration = opt.Duration
@@ -166,6 +209,28 @@ func (db *Database) RunInTransaction(ctx context.Context, cb txCallback, opts ..
 	return tx.CommitTransaction()
 }
 
+// retryDelay : the delay before the next attempt, it's doubled on every attempt and randomly reduced by the jitter
+func retryDelay(opt *options.RetryOptions, attempt int, random float64) time.Duration {
+	delay := opt.Backoff
+	for i := 1; i < attempt && delay > 0 && delay <= math.MaxInt64/2; i++ {
+		delay *= 2
+		if opt.MaxBackoff > 0 && delay >= opt.MaxBackoff {
+			break
+		}
+	}
+	if opt.MaxBackoff > 0 && delay > opt.MaxBackoff {
+		delay = opt.MaxBackoff
+	}
+	jitter := opt.Jitter
+	if jitter > 1 {
+		jitter = 1
+	}
+	if jitter > 0 {
+		delay -= time.Duration(float64(delay) * jitter * random)
+	}
+	return delay
+}
+
 type indexDefinition struct {
 	Indexes []struct {
 		Table   string `yaml:"table"`
@@ -3,6 +3,7 @@ package sqlike
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/RevenueMonster/sqlike/reflext"
 	sqldialect "github.com/RevenueMonster/sqlike/sql/dialect"
@@ -29,6 +30,8 @@ func (tb *Table) DestroyOne(ctx context.Context, delete interface{}, opts ...*op
 		tb.driver,
 		tb.dialect,
 		tb.logger,
+		tb.now(),
+		tb.softDelete(),
 		delete,
 		opt,
 	)
@@ -52,6 +55,8 @@ func (tb *Table) DeleteOne(ctx context.Context, act actions.DeleteOneStatement,
 		tb.driver,
 		tb.dialect,
 		tb.logger,
+		tb.now(),
+		tb.softDelete(),
 		&x.DeleteActions,
 		&opt.DeleteOptions,
 	)
@@ -74,12 +79,14 @@ func (tb *Table) Delete(ctx context.Context, act actions.DeleteStatement, opts .
 		tb.driver,
 		tb.dialect,
 		tb.logger,
+		tb.now(),
+		tb.softDelete(),
 		x,
 		opt,
 	)
 }
 
-func deleteMany(ctx context.Context, dbName, tbName string, driver sqldriver.Driver, dialect sqldialect.Dialect, logger logs.Logger, act *actions.DeleteActions, opt *options.DeleteOptions) (int64, error) {
+func deleteMany(ctx context.Context, dbName, tbName string, driver sqldriver.Driver, dialect sqldialect.Dialect, logger logs.Logger, now time.Time, sd *softDelete, act *actions.DeleteActions, opt *options.DeleteOptions) (int64, error) {
 	if act.Database == "" {
 		act.Database = dbName
 	}
@@ -90,6 +97,24 @@ func deleteMany(ctx context.Context, dbName, tbName string, driver sqldriver.Dri
 		return 0, errors.New("sqlike: empty condition is not allow for delete, please use truncate instead")
 	}
 
+	// soft delete will mark the records as deleted instead
+	if sd != nil && !opt.HardDelete {
+		return update(
+			ctx,
+			dbName,
+			tbName,
+			driver,
+			dialect,
+			logger,
+			sd.markDeleted(act, now),
+			&options.UpdateOptions{Debug: opt.Debug, NoResolution: opt.NoResolution},
+		)
+	}
+
+	if !opt.NoResolution {
+		act.Conditions = withResolution(ctx, act.Conditions)
+	}
+
 	stmt := sqlstmt.AcquireStmt(dialect)
 	defer sqlstmt.ReleaseStmt(stmt)
 	if err := dialect.Delete(stmt, act); err != nil {
@@ -107,12 +132,17 @@ func deleteMany(ctx context.Context, dbName, tbName string, driver sqldriver.Dri
 	return result.RowsAffected()
 }
 
-func destroyOne(ctx context.Context, dbName, tbName, pk string, cache reflext.StructMapper, driver sqldriver.Driver, dialect sqldialect.Dialect, logger logs.Logger, delete interface{}, opt *options.DestroyOneOptions) error {
+// destroyOne : the registered soft delete column of the table takes precedence, otherwise it's looked up from the entity
+func destroyOne(ctx context.Context, dbName, tbName, pk string, cache reflext.StructMapper, driver sqldriver.Driver, dialect sqldialect.Dialect, logger logs.Logger, now time.Time, sd *softDelete, delete interface{}, opt *options.DestroyOneOptions) error {
 	v := reflext.ValueOf(delete)
 	if !v.IsValid() {
 		return ErrInvalidInput
 	}
 
+	if err := beforeDelete(ctx, v); err != nil {
+		return err
+	}
+
 	t := v.Type()
 	cdc := cache.CodecByType(t)
 	x := new(actions.DeleteActions)
@@ -141,6 +171,38 @@ func destroyOne(ctx context.Context, dbName, tbName, pk string, cache reflext.St
 	x.Where(expr.Equal(pkv[0], pkv[1]))
 	x.Limit(1)
 
+	if sd == nil {
+		var err error
+		sd, err = softDeleteOf(cdc.Properties())
+		if err != nil {
+			return err
+		}
+	}
+	// soft delete will mark the record as deleted instead
+	if sd != nil && !opt.HardDelete {
+		affected, err := update(
+			ctx,
+			dbName,
+			tbName,
+			driver,
+			dialect,
+			logger,
+			sd.markDeleted(x, now),
+			&options.UpdateOptions{Debug: opt.Debug, NoResolution: opt.NoResolution},
+		)
+		if err != nil {
+			return err
+		}
+		if affected <= 0 {
+			return errors.New("sqlike: unable to delete entity")
+		}
+		return nil
+	}
+
+	if !opt.NoResolution {
+		x.Conditions = withResolution(ctx, x.Conditions)
+	}
+
 	stmt := sqlstmt.AcquireStmt(dialect)
 	defer sqlstmt.ReleaseStmt(stmt)
 	if err := dialect.Delete(stmt, x); err != nil {
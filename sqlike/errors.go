@@ -13,4 +13,6 @@ var (
 	ErrNilEntity = errors.New("sqlike: entity is <nil>")
 	// ErrNoColumn :
 	ErrNoColumn = errors.New("sqlike: no columns to create index")
+	// ErrConcurrentModification : the version of the record is outdated, it's modified by others
+	ErrConcurrentModification = errors.New("sqlike: concurrent modification, the record has been modified")
 )
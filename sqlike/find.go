@@ -3,6 +3,7 @@ package sqlike
 import (
 	"context"
 	"database/sql"
+	"reflect"
 
 	"github.com/RevenueMonster/sqlike/reflext"
 	"github.com/RevenueMonster/sqlike/sql/codec"
@@ -12,7 +13,6 @@ import (
 	"github.com/RevenueMonster/sqlike/sqlike/actions"
 	"github.com/RevenueMonster/sqlike/sqlike/logs"
 	"github.com/RevenueMonster/sqlike/sqlike/options"
-	"github.com/RevenueMonster/sqlike/sqlike/primitive"
 )
 
 // SingleResult : single result is an interface implementing apis as similar as driver.Result
@@ -35,6 +35,7 @@ func (tb *Table) FindOne(ctx context.Context, act actions.SelectOneStatement, op
 		opt = opts[0]
 	}
 	x.Limit(1)
+	tb.excludeDeleted(&x.FindActions, &opt.FindOptions)
 	rslt := find(
 		ctx,
 		tb.dbName,
@@ -46,12 +47,17 @@ func (tb *Table) FindOne(ctx context.Context, act actions.SelectOneStatement, op
 		tb.logger,
 		&x.FindActions,
 		&opt.FindOptions,
-		opt.FindOptions.LockMode,
+		opt.FindOptions.Lock(),
 	)
 	rslt.close = true
 	if rslt.err != nil {
 		return rslt
 	}
+	if len(opt.Preload) > 0 {
+		rslt.preload = func(v reflect.Value) error {
+			return tb.preload(ctx, v, opt.Preload, &opt.FindOptions)
+		}
+	}
 	if !rslt.Next() {
 		rslt.err = sql.ErrNoRows
 	}
@@ -72,6 +78,7 @@ func (tb *Table) Find(ctx context.Context, act actions.SelectStatement, opts ...
 	if !opt.NoLimit && x.Count < 1 {
 		x.Limit(100)
 	}
+	tb.excludeDeleted(x, opt)
 	csr := find(
 		ctx,
 		tb.dbName,
@@ -83,15 +90,20 @@ func (tb *Table) Find(ctx context.Context, act actions.SelectStatement, opts ...
 		tb.logger,
 		x,
 		opt,
-		opt.LockMode,
+		opt.Lock(),
 	)
 	if csr.err != nil {
 		return nil, csr.err
 	}
+	if len(opt.Preload) > 0 {
+		csr.preload = func(v reflect.Value) error {
+			return tb.preload(ctx, v, opt.Preload, opt)
+		}
+	}
 	return csr, nil
 }
 
-func find(ctx context.Context, dbName, tbName string, cache reflext.StructMapper, cdc codec.Codecer, driver sqldriver.Driver, dialect sqldialect.Dialect, logger logs.Logger, act *actions.FindActions, opt *options.FindOptions, lock options.LockMode) *Result {
+func find(ctx context.Context, dbName, tbName string, cache reflext.StructMapper, cdc codec.Codecer, driver sqldriver.Driver, dialect sqldialect.Dialect, logger logs.Logger, act *actions.FindActions, opt *options.FindOptions, lock options.Lock) *Result {
 	if act.Database == "" {
 		act.Database = dbName
 	}
@@ -99,24 +111,20 @@ func find(ctx context.Context, dbName, tbName string, cache reflext.StructMapper
 		act.Table = tbName
 	}
 
-	groups := extractResolution(ctx)
-	if len(groups) > 0 {
-		if len(act.Conditions.Values) > 0 {
-			act.Conditions.Values = append(act.Conditions.Values, primitive.And)
-		}
-
-		for _, group := range groups {
-			act.Conditions.Values = append(act.Conditions.Values, group.Values...)
-		}
+	if !opt.NoResolution {
+		act.Conditions.Values = withResolution(ctx, act.Conditions.Values)
 	}
 
 	rslt := new(Result)
+	rslt.ctx = ctx
 	rslt.cache = cache
 	rslt.codec = cdc
 
+	act.LockOf, act.LockWait = lock.Of, lock.Wait
+
 	stmt := sqlstmt.AcquireStmt(dialect)
 	defer sqlstmt.ReleaseStmt(stmt)
-	if err := dialect.Select(stmt, act, lock); err != nil {
+	if err := dialect.Select(stmt, act, lock.Mode); err != nil {
 		rslt.err = err
 		return rslt
 	}
@@ -0,0 +1,116 @@
+package sqlike
+
+import (
+	"context"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	sqldriver "github.com/RevenueMonster/sqlike/sql/driver"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/indexes"
+)
+
+// ListForeignKeys : list all the foreign keys of the table.
+func (tb *Table) ListForeignKeys(ctx context.Context) ([]indexes.ForeignKey, error) {
+	stmt := sqlstmt.AcquireStmt(tb.dialect)
+	defer sqlstmt.ReleaseStmt(stmt)
+	tb.dialect.GetForeignKeys(stmt, tb.dbName, tb.name)
+	rows, err := sqldriver.Query(
+		ctx,
+		tb.driver,
+		stmt,
+		tb.logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fks := make([]indexes.ForeignKey, 0)
+	for rows.Next() {
+		var name, col, refTable, refCol, onDelete, onUpdate string
+		if err := rows.Scan(
+			&name,
+			&col,
+			&refTable,
+			&refCol,
+			&onDelete,
+			&onUpdate,
+		); err != nil {
+			return nil, err
+		}
+
+		// composite foreign key will be returned as multiple rows
+		if n := len(fks); n > 0 && fks[n-1].Name == name {
+			fks[n-1].Columns = append(fks[n-1].Columns, col)
+			fks[n-1].RefColumns = append(fks[n-1].RefColumns, refCol)
+			continue
+		}
+		fks = append(fks, indexes.ForeignKey{
+			Name:       name,
+			Columns:    []string{col},
+			RefTable:   refTable,
+			RefColumns: []string{refCol},
+			OnDelete:   onDelete,
+			OnUpdate:   onUpdate,
+		})
+	}
+	return fks, rows.Err()
+}
+
+// diffForeignKeys : compare the declared foreign keys with the existing foreign keys,
+// the foreign key will be dropped and added again if the definition is different and it's unsafe migrate.
+func (tb *Table) diffForeignKeys(ctx context.Context, fields []reflext.StructFielder, unsafe bool) (adds []indexes.ForeignKey, drops []string, err error) {
+	if !tb.alterForeignKeys() {
+		return nil, nil, nil
+	}
+	declared, err := indexes.ForeignKeys(fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	existing, err := tb.ListForeignKeys(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	adds, drops = diffForeignKeys(tb.name, declared, existing, unsafe)
+	return adds, drops, nil
+}
+
+// alterForeignKeys : sqlite only support declaring the foreign keys on `CREATE TABLE`, so the existing table won't be diffed
+func (tb *Table) alterForeignKeys() bool {
+	driver := tb.client.DriverName()
+	return driver != "sqlite" && driver != "sqlite3"
+}
+
+func diffForeignKeys(table string, declared, existing []indexes.ForeignKey, unsafe bool) (adds []indexes.ForeignKey, drops []string) {
+	fks := make(map[string]indexes.ForeignKey, len(existing))
+	for _, fk := range existing {
+		fks[fk.Name] = fk
+	}
+
+	adds, drops = make([]indexes.ForeignKey, 0), make([]string, 0)
+	for _, fk := range declared {
+		name := fk.GetName(table)
+		old, ok := fks[name]
+		delete(fks, name)
+		if ok && old.Equal(fk) {
+			continue
+		}
+		// the changed foreign key is only replaced on unsafe migrate
+		if ok {
+			if !unsafe {
+				continue
+			}
+			drops = append(drops, name)
+		}
+		adds = append(adds, fk)
+	}
+
+	if unsafe {
+		for _, fk := range existing {
+			if _, ok := fks[fk.Name]; ok {
+				drops = append(drops, fk.Name)
+			}
+		}
+	}
+	return
+}
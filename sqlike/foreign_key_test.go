@@ -0,0 +1,47 @@
+package sqlike
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RevenueMonster/sqlike/sqlike/indexes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffForeignKeys(t *testing.T) {
+	declared := []indexes.ForeignKey{
+		{Columns: []string{"UserID"}, RefTable: "User", RefColumns: []string{"ID"}, OnDelete: indexes.Cascade},
+		{Columns: []string{"StoreID"}, RefTable: "Store", RefColumns: []string{"ID"}},
+		{Columns: []string{"MerchantID"}, RefTable: "Merchant", RefColumns: []string{"ID"}},
+	}
+	existing := []indexes.ForeignKey{
+		{Name: "FK_Order_UserID", Columns: []string{"UserID"}, RefTable: "User", RefColumns: []string{"ID"}, OnDelete: "CASCADE", OnUpdate: "NO ACTION"},
+		{Name: "FK_Order_StoreID", Columns: []string{"StoreID"}, RefTable: "Store", RefColumns: []string{"ID"}, OnDelete: "CASCADE"},
+		{Name: "FK_Order_CountryID", Columns: []string{"CountryID"}, RefTable: "Country", RefColumns: []string{"ID"}},
+	}
+
+	// the changed and removed foreign keys are kept
+	adds, drops := diffForeignKeys("Order", declared, existing, false)
+	require.Equal(t, declared[2:], adds)
+	require.Empty(t, drops)
+
+	adds, drops = diffForeignKeys("Order", declared, existing, true)
+	require.Equal(t, declared[1:], adds)
+	require.Equal(t, []string{"FK_Order_StoreID", "FK_Order_CountryID"}, drops)
+
+	adds, drops = diffForeignKeys("Order", nil, nil, true)
+	require.Empty(t, adds)
+	require.Empty(t, drops)
+}
+
+func TestAlterForeignKeys(t *testing.T) {
+	tb := &Table{client: &Client{DriverInfo: &DriverInfo{driverName: "mysql"}}}
+	require.True(t, tb.alterForeignKeys())
+
+	tb.client.driverName = "sqlite3"
+	require.False(t, tb.alterForeignKeys())
+	adds, drops, err := tb.diffForeignKeys(context.Background(), nil, true)
+	require.NoError(t, err)
+	require.Empty(t, adds)
+	require.Empty(t, drops)
+}
@@ -22,6 +22,10 @@ func skipColumns(sfs []reflext.StructFielder, omits util.StringSlice) (fields []
 		if _, ok := sf.Tag().LookUp("generated_column"); ok {
 			continue
 		}
+		// omit all the relationship field, it's not a column of the table
+		if isRelation(sf) {
+			continue
+		}
 		// omit all the field provided by user
 		if length > 0 && omits.IndexOf(sf.Name()) > -1 {
 			continue
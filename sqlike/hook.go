@@ -0,0 +1,62 @@
+package sqlike
+
+import (
+	"context"
+	"reflect"
+)
+
+// BeforeSaver : entity implementing this interface will be invoked before it's inserted or modified, returning an error will abort the operation
+type BeforeSaver interface {
+	BeforeSave(ctx context.Context) error
+}
+
+// AfterLoader : entity implementing this interface will be invoked after it's decoded from the result, returning an error will abort the decoding
+type AfterLoader interface {
+	AfterLoad(ctx context.Context) error
+}
+
+// BeforeDeleter : entity implementing this interface will be invoked before it's destroyed, returning an error will abort the operation
+type BeforeDeleter interface {
+	BeforeDelete(ctx context.Context) error
+}
+
+func beforeSave(ctx context.Context, v reflect.Value) error {
+	if hook, ok := hookOf(v).(BeforeSaver); ok {
+		return hook.BeforeSave(ctx)
+	}
+	return nil
+}
+
+func afterLoad(ctx context.Context, v reflect.Value) error {
+	if hook, ok := hookOf(v).(AfterLoader); ok {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return hook.AfterLoad(ctx)
+	}
+	return nil
+}
+
+func beforeDelete(ctx context.Context, v reflect.Value) error {
+	if hook, ok := hookOf(v).(BeforeDeleter); ok {
+		return hook.BeforeDelete(ctx)
+	}
+	return nil
+}
+
+// hookOf : get the addressable entity, so the hook with pointer receiver can be invoked
+func hookOf(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Kind() != reflect.Ptr && v.CanAddr() {
+		v = v.Addr()
+	}
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+	if !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
@@ -0,0 +1,75 @@
+package sqlike
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type hookEntity struct {
+	Name   string
+	Saved  bool
+	Loaded bool
+}
+
+func (e *hookEntity) BeforeSave(ctx context.Context) error {
+	if e.Name == "" {
+		return errors.New("empty name")
+	}
+	e.Saved = true
+	return nil
+}
+
+func (e *hookEntity) AfterLoad(ctx context.Context) error {
+	e.Loaded = true
+	return nil
+}
+
+func (e hookEntity) BeforeDelete(ctx context.Context) error {
+	if e.Name == "readonly" {
+		return errors.New("readonly entity")
+	}
+	return nil
+}
+
+func TestHook(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("BeforeSave with pointer", func(it *testing.T) {
+		e := &hookEntity{Name: "a"}
+		require.NoError(it, beforeSave(ctx, reflect.ValueOf(e)))
+		require.True(it, e.Saved)
+
+		require.Error(it, beforeSave(ctx, reflect.ValueOf(&hookEntity{})))
+	})
+
+	t.Run("BeforeSave with slice element", func(it *testing.T) {
+		ents := []hookEntity{{Name: "a"}, {Name: "b"}}
+		v := reflect.ValueOf(ents)
+		for i := 0; i < v.Len(); i++ {
+			require.NoError(it, beforeSave(ctx, v.Index(i)))
+		}
+		require.True(it, ents[0].Saved)
+		require.True(it, ents[1].Saved)
+	})
+
+	t.Run("AfterLoad with nil context", func(it *testing.T) {
+		e := hookEntity{}
+		require.NoError(it, afterLoad(nil, reflect.ValueOf(&e).Elem()))
+		require.True(it, e.Loaded)
+	})
+
+	t.Run("BeforeDelete with value", func(it *testing.T) {
+		require.NoError(it, beforeDelete(ctx, reflect.ValueOf(hookEntity{Name: "a"})))
+		require.Error(it, beforeDelete(ctx, reflect.ValueOf(hookEntity{Name: "readonly"})))
+	})
+
+	t.Run("Without hook", func(it *testing.T) {
+		require.NoError(it, beforeSave(ctx, reflect.ValueOf(struct{}{})))
+		require.NoError(it, afterLoad(ctx, reflect.Value{}))
+		require.NoError(it, beforeDelete(ctx, reflect.ValueOf((*hookEntity)(nil))))
+	})
+}
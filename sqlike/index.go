@@ -103,6 +103,10 @@ func (idv IndexView) DropOne(ctx context.Context, name string) error {
 	stmt := sqlstmt.AcquireStmt(idv.tb.dialect)
 	defer sqlstmt.ReleaseStmt(stmt)
 	idv.tb.dialect.DropIndexes(stmt, idv.tb.dbName, idv.tb.name, []string{name})
+	// the dialect doesn't write anything if the index can't be dropped, eg. primary key of postgres
+	if stmt.String() == "" {
+		return nil
+	}
 	_, err := sqldriver.Execute(
 		ctx,
 		idv.tb.driver,
@@ -125,6 +129,9 @@ func (idv *IndexView) DropAll(ctx context.Context) error {
 	stmt := sqlstmt.AcquireStmt(idv.tb.dialect)
 	defer sqlstmt.ReleaseStmt(stmt)
 	idv.tb.dialect.DropIndexes(stmt, idv.tb.dbName, idv.tb.name, names)
+	if stmt.String() == "" {
+		return nil
+	}
 	if _, err := sqldriver.Execute(
 		ctx,
 		idv.tb.driver,
@@ -141,8 +148,10 @@ func (idv *IndexView) isSupportDesc() bool {
 		return *idv.supportDesc
 	}
 	flag := false
-	if idv.tb.client.driverName == "mysql" &&
-		idv.tb.client.version.GreaterThan(mysql8) {
+	switch idv.tb.client.driverName {
+	case "mysql":
+		flag = idv.tb.client.version.GreaterThan(mysql8)
+	case "postgres", "sqlite", "sqlite3":
 		flag = true
 	}
 	idv.supportDesc = &flag
@@ -0,0 +1,115 @@
+package indexes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+)
+
+// reference options :
+const (
+	Restrict   = "RESTRICT"
+	Cascade    = "CASCADE"
+	SetNull    = "SET NULL"
+	SetDefault = "SET DEFAULT"
+	NoAction   = "NO ACTION"
+)
+
+// ForeignKey :
+type ForeignKey struct {
+	Name       string
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+	OnDelete   string
+	OnUpdate   string
+}
+
+// GetName : the default name is `FK_<table>_<columns>`
+func (fk ForeignKey) GetName(table string) string {
+	if fk.Name != "" {
+		return fk.Name
+	}
+	return "FK_" + table + "_" + strings.Join(fk.Columns, "_")
+}
+
+// Equal : whether both foreign keys have the same definition, empty reference option is the same as `RESTRICT` and `NO ACTION`
+func (fk ForeignKey) Equal(o ForeignKey) bool {
+	return fk.RefTable == o.RefTable &&
+		strings.Join(fk.Columns, ",") == strings.Join(o.Columns, ",") &&
+		strings.Join(fk.RefColumns, ",") == strings.Join(o.RefColumns, ",") &&
+		sameReferenceOption(fk.OnDelete, o.OnDelete) &&
+		sameReferenceOption(fk.OnUpdate, o.OnUpdate)
+}
+
+// ForeignKeyOf : parse the foreign key from struct tag, eg. `sqlike:",foreign_key=User.ID,on_delete=cascade,on_update=cascade"`.
+// It will return nil if the field doesn't declare any foreign key.
+func ForeignKeyOf(sf reflext.StructFielder) (*ForeignKey, error) {
+	tag := sf.Tag()
+	v, ok := tag.LookUp("foreign_key")
+	if !ok {
+		return nil, nil
+	}
+
+	idx := strings.LastIndex(v, ".")
+	if idx <= 0 || idx == len(v)-1 {
+		return nil, fmt.Errorf("sqlike: invalid foreign key %q on field %q, it should be `table.column`", v, sf.Name())
+	}
+
+	fk := new(ForeignKey)
+	fk.Columns = []string{sf.Name()}
+	fk.RefTable = v[:idx]
+	fk.RefColumns = []string{v[idx+1:]}
+	if v, ok := tag.LookUp("on_delete"); ok {
+		opt, err := referenceOption(v)
+		if err != nil {
+			return nil, err
+		}
+		fk.OnDelete = opt
+	}
+	if v, ok := tag.LookUp("on_update"); ok {
+		opt, err := referenceOption(v)
+		if err != nil {
+			return nil, err
+		}
+		fk.OnUpdate = opt
+	}
+	return fk, nil
+}
+
+func referenceOption(v string) (string, error) {
+	opt := strings.ToUpper(strings.Join(strings.FieldsFunc(v, func(r rune) bool {
+		return r == '_' || r == ' '
+	}), " "))
+	switch opt {
+	case Restrict, Cascade, SetNull, SetDefault, NoAction:
+		return opt, nil
+	}
+	return "", fmt.Errorf("sqlike: invalid reference option %q", v)
+}
+
+func sameReferenceOption(a, b string) bool {
+	normalize := func(v string) string {
+		if v == "" || v == Restrict {
+			return NoAction
+		}
+		return v
+	}
+	return normalize(strings.ToUpper(a)) == normalize(strings.ToUpper(b))
+}
+
+// ForeignKeys : parse all the foreign keys declared on the struct fields
+func ForeignKeys(fields []reflext.StructFielder) ([]ForeignKey, error) {
+	fks := make([]ForeignKey, 0)
+	for _, sf := range fields {
+		fk, err := ForeignKeyOf(sf)
+		if err != nil {
+			return nil, err
+		}
+		if fk != nil {
+			fks = append(fks, *fk)
+		}
+	}
+	return fks, nil
+}
@@ -0,0 +1,70 @@
+package indexes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForeignKey(t *testing.T) {
+	type order struct {
+		ID       int64
+		UserID   int64  `sqlike:",foreign_key=User.ID,on_delete=cascade"`
+		StoreID  string `sqlike:",foreign_key=public.Store.ID,on_delete=set_null,on_update=NO ACTION"`
+		Remark   string
+		Invalid  string `sqlike:",foreign_key=Store"`
+		Option   string `sqlike:",foreign_key=Store.ID,on_delete=drop"`
+		Trailing string `sqlike:",foreign_key=Store."`
+	}
+
+	fields := reflext.DefaultMapper.CodecByType(reflect.TypeOf(order{})).Properties()
+
+	t.Run("ForeignKeyOf", func(it *testing.T) {
+		fk, err := ForeignKeyOf(fields[0])
+		require.NoError(it, err)
+		require.Nil(it, fk)
+
+		fk, err = ForeignKeyOf(fields[1])
+		require.NoError(it, err)
+		require.Equal(it, &ForeignKey{
+			Columns:    []string{"UserID"},
+			RefTable:   "User",
+			RefColumns: []string{"ID"},
+			OnDelete:   Cascade,
+		}, fk)
+		require.Equal(it, "FK_Order_UserID", fk.GetName("Order"))
+
+		fk, err = ForeignKeyOf(fields[2])
+		require.NoError(it, err)
+		require.Equal(it, &ForeignKey{
+			Columns:    []string{"StoreID"},
+			RefTable:   "public.Store",
+			RefColumns: []string{"ID"},
+			OnDelete:   SetNull,
+			OnUpdate:   NoAction,
+		}, fk)
+
+		for _, sf := range fields[4:] {
+			_, err = ForeignKeyOf(sf)
+			require.Error(it, err)
+		}
+	})
+
+	t.Run("ForeignKeys", func(it *testing.T) {
+		fks, err := ForeignKeys(fields[:4])
+		require.NoError(it, err)
+		require.Len(it, fks, 2)
+
+		_, err = ForeignKeys(fields)
+		require.Error(it, err)
+	})
+
+	t.Run("Equal", func(it *testing.T) {
+		fk := ForeignKey{Columns: []string{"UserID"}, RefTable: "User", RefColumns: []string{"ID"}}
+		require.True(it, fk.Equal(ForeignKey{Name: "fk", Columns: []string{"UserID"}, RefTable: "User", RefColumns: []string{"ID"}, OnDelete: Restrict, OnUpdate: "no action"}))
+		require.False(it, fk.Equal(ForeignKey{Columns: []string{"UserID"}, RefTable: "User", RefColumns: []string{"ID"}, OnDelete: Cascade}))
+		require.False(it, fk.Equal(ForeignKey{Columns: []string{"UserID"}, RefTable: "Users", RefColumns: []string{"ID"}}))
+	})
+}
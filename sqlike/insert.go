@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"reflect"
+	"time"
 
 	"errors"
 
@@ -16,12 +17,18 @@ import (
 	"github.com/RevenueMonster/sqlike/sqlike/options"
 )
 
-// InsertOne : insert single record. You should always pass in the address of input.
+// InsertOne : insert single record. You should always pass in the address of input, or `map[string]interface{}` which the keys are validated against the table columns.
 func (tb *Table) InsertOne(ctx context.Context, src interface{}, opts ...*options.InsertOneOptions) (sql.Result, error) {
 	opt := new(options.InsertOneOptions)
 	if len(opts) > 0 && opts[0] != nil {
 		opt = opts[0]
 	}
+	if records, ok := mapRecords(src); ok {
+		if len(records) != 1 {
+			return nil, ErrInvalidInput
+		}
+		return tb.insertMaps(ctx, records, &opt.InsertOptions)
+	}
 	v := reflect.ValueOf(src)
 	if !v.IsValid() {
 		return nil, ErrInvalidInput
@@ -47,17 +54,25 @@ func (tb *Table) InsertOne(ctx context.Context, src interface{}, opts ...*option
 		tb.driver,
 		tb.dialect,
 		tb.logger,
+		tb.now(),
 		arr.Interface(),
 		&opt.InsertOptions,
 	)
 }
 
-// Insert : insert multiple records. You should always pass in the address of the slice.
+// Insert : insert multiple records. You should always pass in the address of the slice, or `[]map[string]interface{}` which the keys are validated against the table columns.
+// Use `SetBatchSize` to split large slice into multiple statements, the result will be `*BatchResult` and the error will be `*BatchError` if any of the batches failed.
 func (tb *Table) Insert(ctx context.Context, src interface{}, opts ...*options.InsertOptions) (sql.Result, error) {
 	opt := new(options.InsertOptions)
 	if len(opts) > 0 && opts[0] != nil {
 		opt = opts[0]
 	}
+	if records, ok := mapRecords(src); ok {
+		return tb.insertMaps(ctx, records, opt)
+	}
+	if opt.BatchSize > 0 {
+		return tb.insertBatches(ctx, src, opt)
+	}
 	return insertMany(
 		ctx,
 		tb.dbName,
@@ -68,12 +83,13 @@ func (tb *Table) Insert(ctx context.Context, src interface{}, opts ...*options.I
 		tb.driver,
 		tb.dialect,
 		tb.logger,
+		tb.now(),
 		src,
 		opt,
 	)
 }
 
-func insertMany(ctx context.Context, dbName, tbName, pk string, cache reflext.StructMapper, cdc codec.Codecer, driver sqldriver.Driver, dialect sqldialect.Dialect, logger logs.Logger, src interface{}, opt *options.InsertOptions) (sql.Result, error) {
+func insertMany(ctx context.Context, dbName, tbName, pk string, cache reflext.StructMapper, cdc codec.Codecer, driver sqldriver.Driver, dialect sqldialect.Dialect, logger logs.Logger, now time.Time, src interface{}, opt *options.InsertOptions) (sql.Result, error) {
 	v := reflext.ValueOf(src)
 	if !v.IsValid() {
 		return nil, ErrInvalidInput
@@ -95,9 +111,21 @@ func insertMany(ctx context.Context, dbName, tbName, pk string, cache reflext.St
 	}
 
 	def := cache.CodecByType(t)
+	for i := 0; i < v.Len(); i++ {
+		if err := beforeSave(ctx, v.Index(i)); err != nil {
+			return nil, err
+		}
+		if err := autoTimestamps(cache, v.Index(i), def.Properties(), now, true); err != nil {
+			return nil, err
+		}
+	}
+
 	stmt := sqlstmt.AcquireStmt(dialect)
 	defer sqlstmt.ReleaseStmt(stmt)
 
+	// the fields might be modified by dialect, so lookup the `auto_increment` field before building the statement
+	fields := skipRelations(def.Properties())
+	ai := autoIncrementOf(fields, opt)
 	if err := dialect.InsertInto(
 		stmt,
 		dbName,
@@ -105,16 +133,32 @@ func insertMany(ctx context.Context, dbName, tbName, pk string, cache reflext.St
 		pk,
 		cache,
 		cdc,
-		def.Properties(),
+		fields,
 		v,
 		opt,
 	); err != nil {
 		return nil, err
 	}
-	return sqldriver.Execute(
+
+	// populate the generated value of `auto_increment` field back to the records
+	mode := dialect.InsertIDMode()
+	if ai != nil && mode == sqldialect.ReturningInsertID {
+		return insertReturning(ctx, driver, stmt, getLogger(logger, opt.Debug), cache, v, ai)
+	}
+	result, err := sqldriver.Execute(
 		ctx,
 		driver,
 		stmt,
 		getLogger(logger, opt.Debug),
 	)
+	if err != nil {
+		return nil, err
+	}
+	if ai != nil {
+		// the result is returned together with the error, as the records are inserted
+		if err := assignInsertIDs(ctx, driver, cache, v, ai, result, mode, opt); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
 }
@@ -0,0 +1,200 @@
+package sqlike
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	sqldriver "github.com/RevenueMonster/sqlike/sql/driver"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+)
+
+// BatchResult : the aggregated result of batch insert
+type BatchResult struct {
+	lastInsertID  int64
+	lastInsertErr error
+	rowsAffected  int64
+	batches       int
+}
+
+var _ sql.Result = (*BatchResult)(nil)
+
+// LastInsertId : the last insert id of the first batch, it will return error if the first batch is failed
+func (r *BatchResult) LastInsertId() (int64, error) {
+	return r.lastInsertID, r.lastInsertErr
+}
+
+// RowsAffected : the total rows affected of the succeeded batches
+func (r *BatchResult) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
+}
+
+// Batches : the number of batches
+func (r *BatchResult) Batches() int {
+	return r.batches
+}
+
+// BatchFailure : the failure of single batch, `Offset` is the index of the first record of the batch
+type BatchFailure struct {
+	Batch  int
+	Offset int
+	Size   int
+	Err    error
+}
+
+// BatchError : the error of batch insert, the failed batches are not retried and the other batches are not rolled back
+type BatchError struct {
+	Batches  int
+	Failures []BatchFailure
+}
+
+// Error :
+func (e *BatchError) Error() string {
+	f := e.Failures[0]
+	return fmt.Sprintf("sqlike: %d of %d batches failed, batch %d (offset %d, size %d): %v", len(e.Failures), e.Batches, f.Batch, f.Offset, f.Size, f.Err)
+}
+
+// Unwrap : the error of the first failed batch
+func (e *BatchError) Unwrap() error {
+	return e.Failures[0].Err
+}
+
+// insertBatches : split the records into batches, and insert the batches sequentially or concurrently.
+// It will insert all the batches even though some of the batches are failed, and the failures will be reported using `BatchError`.
+func (tb *Table) insertBatches(ctx context.Context, src interface{}, opt *options.InsertOptions) (sql.Result, error) {
+	v := reflext.ValueOf(src)
+	if !v.IsValid() {
+		return nil, ErrInvalidInput
+	}
+	v = reflext.Indirect(v)
+	if !reflext.IsKind(v.Type(), reflect.Array) && !reflext.IsKind(v.Type(), reflect.Slice) {
+		return nil, errors.New("sqlike: insert only support array or slice of entity")
+	}
+	if v.Len() < 1 {
+		return nil, ErrInvalidInput
+	}
+	// array must be addressable to be sliced
+	if v.Kind() == reflect.Array && !v.CanAddr() {
+		slice := reflect.MakeSlice(reflect.SliceOf(v.Type().Elem()), v.Len(), v.Len())
+		reflect.Copy(slice, v)
+		v = slice
+	}
+
+	length, size := v.Len(), opt.BatchSize
+	batches := (length + size - 1) / size
+	concurrency := opt.Concurrency
+	// the connection of transaction can't be used concurrently
+	if _, ok := tb.driver.(*sql.Tx); ok || concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > batches {
+		concurrency = batches
+	}
+
+	var (
+		now     = tb.now()
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, concurrency)
+		results = make([]sql.Result, batches)
+		errs    = make([]error, batches)
+	)
+	for i := 0; i < batches; i++ {
+		end := (i + 1) * size
+		if end > length {
+			end = length
+		}
+		// stop taking the remaining batches once the context is done
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(i int, records reflect.Value) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i], errs[i] = tb.insertBatch(ctx, records, now, opt)
+		}(i, v.Slice(i*size, end))
+	}
+	wg.Wait()
+
+	return aggregateBatches(results, errs, size, length)
+}
+
+func (tb *Table) insertBatch(ctx context.Context, records reflect.Value, now time.Time, opt *options.InsertOptions) (result sql.Result, err error) {
+	var driver sqldriver.Driver = tb.driver
+	if _, ok := driver.(*sql.Tx); opt.BatchTransaction && !ok {
+		var tx *sql.Tx
+		tx, err = tb.client.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err != nil {
+				tx.Rollback()
+				return
+			}
+			err = tx.Commit()
+		}()
+		driver = tx
+	}
+	return insertMany(
+		ctx,
+		tb.dbName,
+		tb.name,
+		tb.pk,
+		tb.client.cache,
+		tb.codec,
+		driver,
+		tb.dialect,
+		tb.logger,
+		now,
+		records.Interface(),
+		opt,
+	)
+}
+
+func aggregateBatches(results []sql.Result, errs []error, size, length int) (sql.Result, error) {
+	rslt := &BatchResult{batches: len(results)}
+	var failures []BatchFailure
+	for i, result := range results {
+		err := errs[i]
+		if err == nil {
+			var affected int64
+			affected, err = result.RowsAffected()
+			rslt.rowsAffected += affected
+		}
+		// some driver doesn't support last insert id, eg. postgres
+		if i == 0 {
+			if err != nil {
+				rslt.lastInsertErr = fmt.Errorf("sqlike: no last insert id as the first batch failed: %w", err)
+			} else {
+				rslt.lastInsertID, _ = result.LastInsertId()
+			}
+		}
+		if err != nil {
+			offset := i * size
+			n := size
+			if offset+n > length {
+				n = length - offset
+			}
+			failures = append(failures, BatchFailure{Batch: i, Offset: offset, Size: n, Err: err})
+		}
+	}
+	if len(failures) > 0 {
+		return rslt, &BatchError{Batches: len(results), Failures: failures}
+	}
+	return rslt, nil
+}
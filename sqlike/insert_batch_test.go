@@ -0,0 +1,167 @@
+package sqlike
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/codec"
+	"github.com/RevenueMonster/sqlike/sql/dialect/mysql"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+type batchResult int64
+
+func (r batchResult) LastInsertId() (int64, error) { return int64(r) * 100, nil }
+func (r batchResult) RowsAffected() (int64, error) { return int64(r), nil }
+
+// batchDriver : fake driver which records the executed statements, the statement will fail if any of the args is "fail"
+type batchDriver struct {
+	mu      sync.Mutex
+	queries []string
+}
+
+func (d *batchDriver) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	d.mu.Lock()
+	d.queries = append(d.queries, query)
+	d.mu.Unlock()
+	for _, arg := range args {
+		if arg == "fail" {
+			return nil, errors.New("duplicate entry")
+		}
+	}
+	return batchResult(strings.Count(query, "(?,?)")), nil
+}
+
+func (d *batchDriver) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errors.New("not supported")
+}
+
+func (d *batchDriver) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+
+func TestInsertBatches(t *testing.T) {
+	type batchEntity struct {
+		ID   int64
+		Name string
+	}
+
+	newTable := func() (*Table, *batchDriver) {
+		driver := new(batchDriver)
+		return &Table{
+			dbName:  "db",
+			name:    "User",
+			pk:      "$Key",
+			client:  &Client{cache: reflext.DefaultMapper},
+			driver:  driver,
+			dialect: mysql.New(),
+			codec:   codec.DefaultRegistry,
+		}, driver
+	}
+	records := func(n int) []batchEntity {
+		ents := make([]batchEntity, n)
+		for i := range ents {
+			ents[i] = batchEntity{ID: int64(i + 1), Name: "test"}
+		}
+		return ents
+	}
+	ctx := context.Background()
+
+	t.Run("sequential", func(it *testing.T) {
+		tb, driver := newTable()
+		ents := records(7)
+		result, err := tb.Insert(ctx, &ents, options.Insert().SetBatchSize(3))
+		require.NoError(it, err)
+		require.Len(it, driver.queries, 3)
+		require.Equal(it, "INSERT INTO `db`.`User` (`ID`,`Name`) VALUES (?,?),(?,?),(?,?);", driver.queries[0])
+		require.Equal(it, "INSERT INTO `db`.`User` (`ID`,`Name`) VALUES (?,?);", driver.queries[2])
+
+		affected, err := result.RowsAffected()
+		require.NoError(it, err)
+		require.Equal(it, int64(7), affected)
+		id, err := result.LastInsertId()
+		require.NoError(it, err)
+		require.Equal(it, int64(300), id)
+		require.Equal(it, 3, result.(*BatchResult).Batches())
+	})
+
+	t.Run("concurrent with failures", func(it *testing.T) {
+		tb, driver := newTable()
+		ents := records(10)
+		ents[4].Name = "fail"
+		ents[9].Name = "fail"
+		result, err := tb.Insert(ctx, ents, options.Insert().SetBatchSize(4).SetConcurrency(3))
+		require.Len(it, driver.queries, 3)
+
+		var batchErr *BatchError
+		require.True(it, errors.As(err, &batchErr))
+		require.Equal(it, 3, batchErr.Batches)
+		require.Equal(it, []BatchFailure{
+			{Batch: 1, Offset: 4, Size: 4, Err: batchErr.Failures[0].Err},
+			{Batch: 2, Offset: 8, Size: 2, Err: batchErr.Failures[1].Err},
+		}, batchErr.Failures)
+		require.EqualError(it, err, "sqlike: 2 of 3 batches failed, batch 1 (offset 4, size 4): duplicate entry")
+		require.EqualError(it, errors.Unwrap(err), "duplicate entry")
+
+		affected, _ := result.RowsAffected()
+		require.Equal(it, int64(4), affected)
+	})
+
+	t.Run("first batch failed", func(it *testing.T) {
+		tb, _ := newTable()
+		ents := records(5)
+		ents[0].Name = "fail"
+		result, err := tb.Insert(ctx, ents, options.Insert().SetBatchSize(2))
+		require.Error(it, err)
+
+		affected, _ := result.RowsAffected()
+		require.Equal(it, int64(3), affected)
+		_, err = result.LastInsertId()
+		require.EqualError(it, err, "sqlike: no last insert id as the first batch failed: duplicate entry")
+	})
+
+	t.Run("context cancelled", func(it *testing.T) {
+		tb, driver := newTable()
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := tb.Insert(cctx, records(5), options.Insert().SetBatchSize(2).SetConcurrency(2))
+		require.Empty(it, driver.queries)
+
+		var batchErr *BatchError
+		require.True(it, errors.As(err, &batchErr))
+		require.Len(it, batchErr.Failures, 3)
+		require.True(it, errors.Is(err, context.Canceled))
+	})
+
+	t.Run("sequential in transaction", func(it *testing.T) {
+		db, d := newTxDatabase(it)
+		ents := records(5)
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			_, err := sess.Table("User").Insert(sess, ents, options.Insert().SetBatchSize(2).SetConcurrency(3))
+			return err
+		})
+		require.NoError(it, err)
+		require.Equal(it, []string{
+			"BEGIN",
+			"INSERT INTO `db`.`User` (`ID`,`Name`) VALUES (?,?),(?,?);",
+			"INSERT INTO `db`.`User` (`ID`,`Name`) VALUES (?,?),(?,?);",
+			"INSERT INTO `db`.`User` (`ID`,`Name`) VALUES (?,?);",
+			"COMMIT",
+		}, d.history())
+	})
+
+	t.Run("invalid", func(it *testing.T) {
+		tb, _ := newTable()
+		_, err := tb.Insert(ctx, []batchEntity{}, options.Insert().SetBatchSize(3))
+		require.Equal(it, ErrInvalidInput, err)
+
+		_, err = tb.Insert(ctx, batchEntity{}, options.Insert().SetBatchSize(3))
+		require.Error(it, err)
+	})
+}
@@ -0,0 +1,312 @@
+package sqlike
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+)
+
+// mapRecords : accept `map[string]interface{}`, `[]map[string]interface{}` and their pointers
+func mapRecords(src interface{}) ([]map[string]interface{}, bool) {
+	switch vi := src.(type) {
+	case map[string]interface{}:
+		return []map[string]interface{}{vi}, true
+	case *map[string]interface{}:
+		if vi == nil {
+			return nil, false
+		}
+		return []map[string]interface{}{*vi}, true
+	case []map[string]interface{}:
+		return vi, true
+	case *[]map[string]interface{}:
+		if vi == nil {
+			return nil, false
+		}
+		return *vi, true
+	}
+	return nil, false
+}
+
+// insertMaps : the records are validated against the columns of the table, and converted to struct,
+// so it will be inserted the same way as struct. The records are grouped by their keys and every group is
+// inserted with its own statement, so the missing key will get the default value of the column instead of null.
+// The groups are inserted in a transaction unless the table is already in a transaction.
+// Update from map is not supported, use `expr.ColumnValue` on `UpdateOne` or `UpdateMany` instead.
+func (tb *Table) insertMaps(ctx context.Context, records []map[string]interface{}, opt *options.InsertOptions) (result sql.Result, err error) {
+	if len(records) < 1 {
+		return nil, ErrInvalidInput
+	}
+	columns, err := tb.ListColumns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := groupRecords(tb.name, columns, records)
+	if err != nil {
+		return nil, err
+	}
+	// the groups are inserted in a transaction, so the previous groups are rolled back when any of the group failed
+	if _, ok := tb.driver.(*sql.Tx); !ok && len(groups) > 1 {
+		var tx *sql.Tx
+		tx, err = tb.client.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err != nil {
+				tx.Rollback()
+				return
+			}
+			err = tx.Commit()
+		}()
+		x := *tb
+		x.driver = tx
+		return x.insertGroups(ctx, columns, groups, opt)
+	}
+	return tb.insertGroups(ctx, columns, groups, opt)
+}
+
+func (tb *Table) insertGroups(ctx context.Context, columns []Column, groups [][]map[string]interface{}, opt *options.InsertOptions) (sql.Result, error) {
+	results := make([]sql.Result, 0, len(groups))
+	for _, group := range groups {
+		v, err := mapsToStructs(tb.name, columns, group)
+		if err != nil {
+			return nil, err
+		}
+		var result sql.Result
+		if opt.BatchSize > 0 {
+			result, err = tb.insertBatches(ctx, v.Interface(), opt)
+		} else {
+			result, err = insertMany(
+				ctx,
+				tb.dbName,
+				tb.name,
+				tb.pk,
+				tb.client.cache,
+				tb.codec,
+				tb.driver,
+				tb.dialect,
+				tb.logger,
+				tb.now(),
+				v.Interface(),
+				opt,
+			)
+		}
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	if len(results) == 1 {
+		return results[0], nil
+	}
+	// every group is a batch, the last insert id is the one of the first group
+	rslt := &BatchResult{batches: len(results)}
+	for i, result := range results {
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		rslt.rowsAffected += affected
+		if i == 0 {
+			rslt.lastInsertID, _ = result.LastInsertId()
+			if br, ok := result.(*BatchResult); ok {
+				rslt.lastInsertErr = br.lastInsertErr
+			}
+		}
+	}
+	return rslt, nil
+}
+
+// groupRecords : group the records by their keys, the order of the groups follows the first record of each group
+func groupRecords(table string, columns []Column, records []map[string]interface{}) ([][]map[string]interface{}, error) {
+	exists := make(map[string]bool, len(columns))
+	for _, col := range columns {
+		exists[col.Name] = true
+	}
+	idx := make(map[string]int)
+	groups := make([][]map[string]interface{}, 0, 1)
+	for _, record := range records {
+		if len(record) == 0 {
+			return nil, ErrInvalidInput
+		}
+		for k := range record {
+			if !exists[k] {
+				return nil, fmt.Errorf("sqlike: unknown column %q in table %q", k, table)
+			}
+		}
+		// the keys are sorted by the column position
+		blr := new(strings.Builder)
+		for _, col := range columns {
+			if _, ok := record[col.Name]; ok {
+				blr.WriteString(strconv.Quote(col.Name))
+			}
+		}
+		key := blr.String()
+		i, ok := idx[key]
+		if !ok {
+			i = len(groups)
+			idx[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], record)
+	}
+	return groups, nil
+}
+
+// mapsToStructs : convert the records to slice of struct, the fields are ordered by the table columns.
+// The type of the field follows the column type, and every field is a pointer, so nil value will be inserted as null.
+func mapsToStructs(table string, columns []Column, records []map[string]interface{}) (reflect.Value, error) {
+	exists := make(map[string]bool, len(columns))
+	for _, col := range columns {
+		exists[col.Name] = true
+	}
+	used := make(map[string]bool)
+	for _, record := range records {
+		if len(record) == 0 {
+			return reflect.Value{}, ErrInvalidInput
+		}
+		for k := range record {
+			if !exists[k] {
+				return reflect.Value{}, fmt.Errorf("sqlike: unknown column %q in table %q", k, table)
+			}
+			used[k] = true
+		}
+	}
+
+	keys := make([]string, 0, len(used))
+	types := make([]reflect.Type, 0, len(used))
+	fields := make([]reflect.StructField, 0, len(used))
+	for _, col := range columns {
+		name := col.Name
+		if !used[name] {
+			continue
+		}
+		// fallback to the type of the first non-nil value if the column type is not numeric or string, eg. datetime, json
+		t := columnGoType(col)
+		if t == nil {
+			t = reflect.TypeOf((*interface{})(nil)).Elem()
+			for _, record := range records {
+				if val := record[name]; val != nil {
+					t = reflect.TypeOf(val)
+					break
+				}
+			}
+		}
+		fields = append(fields, reflect.StructField{
+			Name: "F" + strconv.Itoa(len(fields)),
+			Type: reflect.PtrTo(t),
+			Tag:  reflect.StructTag(`sqlike:` + strconv.Quote(name)),
+		})
+		keys = append(keys, name)
+		types = append(types, t)
+	}
+
+	t := reflect.StructOf(fields)
+	slice := reflect.MakeSlice(reflect.SliceOf(t), len(records), len(records))
+	for i, record := range records {
+		ev := slice.Index(i)
+		for j, name := range keys {
+			val, ok := record[name]
+			if !ok || val == nil {
+				continue
+			}
+			fv, err := convertValue(name, reflect.ValueOf(val), types[j])
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			ptr := reflect.New(types[j])
+			ptr.Elem().Set(fv)
+			ev.Field(j).Set(ptr)
+		}
+	}
+	return slice, nil
+}
+
+// columnGoType : the go type of the column, it will be nil if the column isn't numeric or string,
+// `TINYINT(1)` is excluded as it's used as boolean in mysql
+func columnGoType(col Column) reflect.Type {
+	switch col.DataType {
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "INTEGER", "BIGINT":
+		if col.Type == "TINYINT(1)" {
+			return nil
+		}
+		if strings.Contains(col.Type, "UNSIGNED") {
+			return reflect.TypeOf(uint64(0))
+		}
+		return reflect.TypeOf(int64(0))
+	case "FLOAT", "DOUBLE", "REAL", "DOUBLE PRECISION":
+		return reflect.TypeOf(float64(0))
+	case "CHAR", "VARCHAR", "CHARACTER", "CHARACTER VARYING",
+		"TINYTEXT", "TEXT", "MEDIUMTEXT", "LONGTEXT", "ENUM":
+		return reflect.TypeOf("")
+	case "BOOLEAN", "BOOL":
+		return reflect.TypeOf(false)
+	default:
+		return nil
+	}
+}
+
+// convertValue : convert the value to the type of the column, the conversion must not lose the precision,
+// eg. `1.5` or `-1` is invalid for `BIGINT UNSIGNED` column
+func convertValue(name string, v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	if v.Type() == t {
+		return v, nil
+	}
+	if !convertible(v.Type(), t) {
+		return reflect.Value{}, fmt.Errorf("sqlike: invalid type of column %q, expected %v but got %v", name, t, v.Type())
+	}
+	if !isNumeric(t.Kind()) {
+		return v.Convert(t), nil
+	}
+	cv := v.Convert(t)
+	// negative value wraps around on unsigned type, otherwise convert back to make sure the value is the same
+	if (isUnsigned(t.Kind()) && isNegative(v)) || !reflect.DeepEqual(cv.Convert(v.Type()).Interface(), v.Interface()) {
+		return reflect.Value{}, fmt.Errorf("sqlike: invalid value %v of column %q, it overflows or loses precision on %v", v.Interface(), name, t)
+	}
+	return cv, nil
+}
+
+// convertible : only the numeric types or the types with same kind are convertible, eg. `int` to `int64`,
+// as `int` to `string` is convertible in golang but it's not expected.
+func convertible(from, to reflect.Type) bool {
+	if !from.ConvertibleTo(to) {
+		return false
+	}
+	return from.Kind() == to.Kind() || (isNumeric(from.Kind()) && isNumeric(to.Kind()))
+}
+
+func isNumeric(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isUnsigned(k reflect.Kind) bool {
+	switch k {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isNegative(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() < 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() < 0
+	default:
+		return false
+	}
+}
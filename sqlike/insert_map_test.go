@@ -0,0 +1,100 @@
+package sqlike
+
+import (
+	"testing"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/codec"
+	"github.com/RevenueMonster/sqlike/sql/dialect/mysql"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertMap(t *testing.T) {
+	columns := []Column{
+		{Name: "ID", Type: "BIGINT(20)", DataType: "BIGINT"},
+		{Name: "Name", Type: "VARCHAR(191)", DataType: "VARCHAR"},
+		{Name: "Age", Type: "TINYINT(3) UNSIGNED", DataType: "TINYINT"},
+		{Name: "Remark", Type: "JSON", DataType: "JSON"},
+	}
+
+	t.Run("mapRecords", func(it *testing.T) {
+		m := map[string]interface{}{"ID": 1}
+		records, ok := mapRecords(m)
+		require.True(it, ok)
+		require.Len(it, records, 1)
+
+		records, ok = mapRecords(&[]map[string]interface{}{m, m})
+		require.True(it, ok)
+		require.Len(it, records, 2)
+
+		_, ok = mapRecords(&struct{}{})
+		require.False(it, ok)
+	})
+
+	t.Run("mapsToStructs", func(it *testing.T) {
+		v, err := mapsToStructs("User", columns, []map[string]interface{}{
+			{"Name": "John", "ID": int64(1), "Remark": nil},
+			{"ID": 2, "Age": uint8(18)},
+		})
+		require.NoError(it, err)
+		require.Equal(it, 2, v.Len())
+
+		ms := mysql.New()
+		stmt := sqlstmt.AcquireStmt(ms)
+		defer sqlstmt.ReleaseStmt(stmt)
+
+		cdc := reflext.DefaultMapper.CodecByType(v.Type().Elem())
+		require.NoError(it, ms.InsertInto(stmt, "db", "User", "$Key", reflext.DefaultMapper, codec.DefaultRegistry, cdc.Properties(), v, options.Insert()))
+		require.Equal(it, "INSERT INTO `db`.`User` (`ID`,`Name`,`Age`,`Remark`) VALUES (?,?,?,?),(?,?,?,?);", stmt.String())
+		require.Equal(it, []interface{}{int64(1), "John", nil, nil, int64(2), nil, uint64(18), nil}, stmt.Args())
+	})
+
+	t.Run("invalid", func(it *testing.T) {
+		_, err := mapsToStructs("User", columns, []map[string]interface{}{{"Unknown": 1}})
+		require.EqualError(it, err, `sqlike: unknown column "Unknown" in table "User"`)
+
+		_, err = mapsToStructs("User", columns, []map[string]interface{}{{"Name": "John"}, {"Name": 10}})
+		require.Error(it, err)
+
+		_, err = mapsToStructs("User", columns, []map[string]interface{}{{}})
+		require.Equal(it, ErrInvalidInput, err)
+
+		_, err = mapsToStructs("User", columns, []map[string]interface{}{{"ID": 1.5}})
+		require.EqualError(it, err, `sqlike: invalid value 1.5 of column "ID", it overflows or loses precision on int64`)
+
+		_, err = mapsToStructs("User", columns, []map[string]interface{}{{"Age": -1}})
+		require.EqualError(it, err, `sqlike: invalid value -1 of column "Age", it overflows or loses precision on uint64`)
+
+		_, err = groupRecords("User", columns, []map[string]interface{}{{"ID": 1}, {"Unknown": 1}})
+		require.EqualError(it, err, `sqlike: unknown column "Unknown" in table "User"`)
+	})
+
+	t.Run("groupRecords", func(it *testing.T) {
+		groups, err := groupRecords("User", columns, []map[string]interface{}{
+			{"ID": 1, "Name": "John"},
+			{"ID": 2},
+			{"Name": "Doe", "ID": 3},
+			{"ID": 4, "Name": nil},
+		})
+		require.NoError(it, err)
+		require.Equal(it, [][]map[string]interface{}{
+			{{"ID": 1, "Name": "John"}, {"Name": "Doe", "ID": 3}, {"ID": 4, "Name": nil}},
+			{{"ID": 2}},
+		}, groups)
+
+		// the missing key is not inserted, so the column will get its default value
+		v, err := mapsToStructs("User", columns, groups[1])
+		require.NoError(it, err)
+
+		ms := mysql.New()
+		stmt := sqlstmt.AcquireStmt(ms)
+		defer sqlstmt.ReleaseStmt(stmt)
+
+		cdc := reflext.DefaultMapper.CodecByType(v.Type().Elem())
+		require.NoError(it, ms.InsertInto(stmt, "db", "User", "$Key", reflext.DefaultMapper, codec.DefaultRegistry, cdc.Properties(), v, options.Insert()))
+		require.Equal(it, "INSERT INTO `db`.`User` (`ID`) VALUES (?);", stmt.String())
+		require.Equal(it, []interface{}{int64(2)}, stmt.Args())
+	})
+}
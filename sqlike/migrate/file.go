@@ -0,0 +1,189 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// VersionFormat : the version of generated migration is the UTC timestamp follows by this layout
+const VersionFormat = "20060102150405"
+
+var fileRegex = regexp.MustCompile(`^(\d+)_([\w\-]+)\.(up|down)\.sql$`)
+
+// Migration : a single migration which consists of `up` and `down` script, loaded from `<version>_<name>.up.sql` and `<version>_<name>.down.sql`
+type Migration struct {
+	Version string
+	Name    string
+	Up      string
+	Down    string
+}
+
+// UpStatements :
+func (m Migration) UpStatements() []string {
+	return splitStatements(m.Up)
+}
+
+// DownStatements :
+func (m Migration) DownStatements() []string {
+	return splitStatements(m.Down)
+}
+
+// Load : load all the migrations from the folder, sorted by version in ascending order
+func Load(dir string) ([]Migration, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make(map[string]*Migration)
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		paths := fileRegex.FindStringSubmatch(f.Name())
+		if len(paths) < 4 {
+			continue
+		}
+
+		version, name := paths[1], paths[2]
+		m, ok := migrations[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			migrations[version] = m
+		}
+		if m.Name != name {
+			return nil, fmt.Errorf("migrate: duplicate migration version %q (%s and %s)", version, m.Name, name)
+		}
+
+		b, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if paths[3] == "up" {
+			m.Up = string(b)
+		} else {
+			m.Down = string(b)
+		}
+	}
+
+	results := make([]Migration, 0, len(migrations))
+	for _, m := range migrations {
+		results = append(results, *m)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return compareVersion(results[i].Version, results[j].Version) < 0
+	})
+	return results, nil
+}
+
+// Write : write the `up` and `down` script of the migration into the folder
+func Write(dir string, m Migration) error {
+	if !fileRegex.MatchString(fileName(m, "up")) {
+		return fmt.Errorf("migrate: invalid migration version %q or name %q", m.Version, m.Name)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, fileName(m, "up")), []byte(m.Up), 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, fileName(m, "down")), []byte(m.Down), 0644)
+}
+
+func fileName(m Migration, direction string) string {
+	return m.Version + "_" + m.Name + "." + direction + ".sql"
+}
+
+// nextVersion : the version must be greater than the existing versions, so the migrations generated within the same second won't collide
+func nextVersion(version string, migrations []Migration) string {
+	if len(migrations) == 0 {
+		return version
+	}
+	latest := migrations[len(migrations)-1].Version
+	if compareVersion(version, latest) > 0 {
+		return version
+	}
+	n, err := strconv.ParseUint(latest, 10, 64)
+	if err != nil {
+		return version
+	}
+	return strconv.FormatUint(n+1, 10)
+}
+
+// compareVersion : compare the version numerically, fallback to string comparison if it's not a number
+func compareVersion(a, b string) int {
+	x, err1 := strconv.ParseUint(a, 10, 64)
+	y, err2 := strconv.ParseUint(b, 10, 64)
+	if err1 != nil || err2 != nil {
+		return strings.Compare(a, b)
+	}
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// splitStatements : split the script into statements by semicolon, semicolon inside quotes and comments will be ignored
+func splitStatements(script string) []string {
+	var (
+		stmts = make([]string, 0)
+		blr   strings.Builder
+		quote rune
+	)
+
+	flush := func() {
+		query := strings.TrimSpace(blr.String())
+		if query != "" {
+			stmts = append(stmts, query)
+		}
+		blr.Reset()
+	}
+
+	runes := []rune(script)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			blr.WriteRune(r)
+			if r == '\\' && quote != '`' && i+1 < len(runes) {
+				i++
+				blr.WriteRune(runes[i])
+				continue
+			}
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+			blr.WriteRune(r)
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			// skip line comment
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			blr.WriteRune('\n')
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			// skip block comment
+			i += 2
+			for i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/') {
+				i++
+			}
+			i++
+		case r == ';':
+			flush()
+		default:
+			blr.WriteRune(r)
+		}
+	}
+	flush()
+	return stmts
+}
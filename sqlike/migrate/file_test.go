@@ -0,0 +1,85 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"10_add_email.up.sql":       "ALTER TABLE `User` ADD `Email` VARCHAR(191);",
+		"10_add_email.down.sql":     "ALTER TABLE `User` DROP COLUMN `Email`;",
+		"2_create_user.up.sql":      "CREATE TABLE `User` (`ID` INT);",
+		"2_create_user.down.sql":    "DROP TABLE `User`;",
+		"README.md":                 "ignored",
+		"3_invalid.sideways.sql":    "ignored",
+		"20220101_seed-data.up.sql": "INSERT INTO `User` VALUES (1);",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	migrations, err := Load(dir)
+	require.NoError(t, err)
+	require.Equal(t, []Migration{
+		{Version: "2", Name: "create_user", Up: "CREATE TABLE `User` (`ID` INT);", Down: "DROP TABLE `User`;"},
+		{Version: "10", Name: "add_email", Up: "ALTER TABLE `User` ADD `Email` VARCHAR(191);", Down: "ALTER TABLE `User` DROP COLUMN `Email`;"},
+		{Version: "20220101", Name: "seed-data", Up: "INSERT INTO `User` VALUES (1);"},
+	}, migrations)
+
+	t.Run("Duplicate Version", func(it *testing.T) {
+		require.NoError(it, os.WriteFile(filepath.Join(dir, "2_other.up.sql"), []byte(""), 0644))
+		_, err := Load(dir)
+		require.Error(it, err)
+	})
+
+	t.Run("Missing Folder", func(it *testing.T) {
+		_, err := Load(filepath.Join(dir, "unknown"))
+		require.Error(it, err)
+	})
+}
+
+func TestWrite(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "migrations")
+	mg := Migration{Version: "20220101000000", Name: "init", Up: "CREATE TABLE `A` (`ID` INT);\n", Down: "DROP TABLE `A`;\n"}
+	require.NoError(t, Write(dir, mg))
+
+	migrations, err := Load(dir)
+	require.NoError(t, err)
+	require.Equal(t, []Migration{mg}, migrations)
+
+	require.Error(t, Write(dir, Migration{Version: "v1", Name: "init"}))
+	require.Error(t, Write(dir, Migration{Version: "1", Name: "with space"}))
+}
+
+func TestSplitStatements(t *testing.T) {
+	require.Equal(t, []string{}, splitStatements(""))
+	require.Equal(t, []string{}, splitStatements(" ;\n; -- only comment"))
+	require.Equal(t, []string{"SELECT 1"}, splitStatements("SELECT 1"))
+	require.Equal(t, []string{
+		"CREATE TABLE `a;b` (`ID` INT)",
+		"INSERT INTO `a;b` VALUES ('x;y', \"z;\")",
+		"UPDATE `a;b` SET `c` = 'it\\'s;'",
+		"SELECT \n1",
+	}, splitStatements(`
+-- create table; with comment
+CREATE TABLE `+"`a;b`"+` (`+"`ID`"+` INT);
+INSERT INTO `+"`a;b`"+` VALUES ('x;y', "z;"); /* block; comment */
+UPDATE `+"`a;b`"+` SET `+"`c`"+` = 'it\'s;';
+SELECT -- trailing; comment
+1;
+`))
+}
+
+func TestNextVersion(t *testing.T) {
+	require.Equal(t, "20210101000000", nextVersion("20210101000000", nil))
+
+	migrations := []Migration{{Version: "20201231000000"}, {Version: "20210101000000"}}
+	require.Equal(t, "20210101000001", nextVersion("20210101000000", migrations))
+	require.Equal(t, "20210101000001", nextVersion("20201231000000", migrations))
+	require.Equal(t, "20210102000000", nextVersion("20210102000000", migrations))
+}
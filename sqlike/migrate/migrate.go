@@ -0,0 +1,221 @@
+// Package migrate provides versioned migration (django-style) on top of sqlike.
+//
+// Migrations are plain sql files named `<version>_<name>.up.sql` and `<version>_<name>.down.sql`.
+// Applied migrations are recorded in a bookkeeping table, each step is executed inside a transaction.
+// Please take note that mysql will implicitly commit on DDL statement, so the step may not be rolled back fully.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/RevenueMonster/sqlike/sqlike"
+	"github.com/RevenueMonster/sqlike/sqlike/actions"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+)
+
+// DefaultTable : the default bookkeeping table name
+const DefaultTable = "sqlike_migrations"
+
+type record struct {
+	Version   string `sqlike:",primary_key,size=64"`
+	Name      string
+	AppliedAt time.Time
+}
+
+// Status : the status of the migration
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// Migrator :
+type Migrator struct {
+	db      *sqlike.Database
+	dir     string
+	table   string
+	timeout time.Duration
+}
+
+// New : create a migrator which load the migration files from `dir`
+func New(db *sqlike.Database, dir string) *Migrator {
+	return &Migrator{
+		db:      db,
+		dir:     dir,
+		table:   DefaultTable,
+		timeout: 5 * time.Minute,
+	}
+}
+
+// SetTable : set the bookkeeping table name
+func (m *Migrator) SetTable(name string) *Migrator {
+	m.table = name
+	return m
+}
+
+// SetTimeOut : set the timeout of each migration step
+func (m *Migrator) SetTimeOut(duration time.Duration) *Migrator {
+	m.timeout = duration
+	return m
+}
+
+// Up : apply all the pending migrations in order, and return number of migrations applied
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	migrations, applied, err := m.load(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, mg := range migrations {
+		if _, ok := applied[mg.Version]; ok {
+			continue
+		}
+		if err := m.run(ctx, mg.UpStatements(), func(sess sqlike.SessionContext) error {
+			_, err := sess.Table(m.table).InsertOne(sess, &record{
+				Version:   mg.Version,
+				Name:      mg.Name,
+				AppliedAt: time.Now().UTC(),
+			})
+			return err
+		}); err != nil {
+			return count, fmt.Errorf("migrate: up %s_%s: %w", mg.Version, mg.Name, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Down : revert the latest `steps` applied migrations, and return number of migrations reverted
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	migrations, applied, err := m.load(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := len(migrations) - 1; i >= 0 && count < steps; i-- {
+		mg := migrations[i]
+		rec, ok := applied[mg.Version]
+		if !ok {
+			continue
+		}
+		if err := m.run(ctx, mg.DownStatements(), func(sess sqlike.SessionContext) error {
+			return sess.Table(m.table).DestroyOne(sess, &rec)
+		}); err != nil {
+			return count, fmt.Errorf("migrate: down %s_%s: %w", mg.Version, mg.Name, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Status : return the status of all the migrations
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	migrations, applied, err := m.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Status, len(migrations))
+	for i, mg := range migrations {
+		results[i].Migration = mg
+		if rec, ok := applied[mg.Version]; ok {
+			appliedAt := rec.AppliedAt
+			results[i].Applied = true
+			results[i].AppliedAt = &appliedAt
+		}
+	}
+	return results, nil
+}
+
+// Generate : generate a new migration file by diffing the entities against the existing tables, the key of `tables` is the table name.
+// It will return nil if there is nothing to migrate.
+func (m *Migrator) Generate(ctx context.Context, name string, tables map[string]interface{}) (*Migration, error) {
+	names := make([]string, 0, len(tables))
+	for k := range tables {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	ups, downs := make([]string, 0), make([]string, 0)
+	for _, k := range names {
+		up, down, err := m.db.Table(k).MigrateStatements(ctx, tables[k])
+		if err != nil {
+			return nil, err
+		}
+		ups = append(ups, up...)
+		// down statements should be reverted in reverse order
+		downs = append(down, downs...)
+	}
+
+	if len(ups) == 0 {
+		return nil, nil
+	}
+
+	migrations, err := Load(m.dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	mg := Migration{
+		Version: nextVersion(time.Now().UTC().Format(VersionFormat), migrations),
+		Name:    name,
+		Up:      joinStatements(ups),
+		Down:    joinStatements(downs),
+	}
+	if err := Write(m.dir, mg); err != nil {
+		return nil, err
+	}
+	return &mg, nil
+}
+
+func (m *Migrator) load(ctx context.Context) ([]Migration, map[string]record, error) {
+	migrations, err := Load(m.dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tb := m.db.Table(m.table)
+	if err := tb.Migrate(ctx, record{}); err != nil {
+		return nil, nil, err
+	}
+
+	result, err := tb.Find(ctx, actions.Find())
+	if err != nil {
+		return nil, nil, err
+	}
+	recs := []record{}
+	if err := result.All(&recs); err != nil {
+		return nil, nil, err
+	}
+
+	applied := make(map[string]record, len(recs))
+	for _, rec := range recs {
+		applied[rec.Version] = rec
+	}
+	return migrations, applied, nil
+}
+
+func (m *Migrator) run(ctx context.Context, stmts []string, bookkeep func(sess sqlike.SessionContext) error) error {
+	return m.db.RunInTransaction(ctx, func(sess sqlike.SessionContext) error {
+		for _, stmt := range stmts {
+			if _, err := sess.Exec(stmt); err != nil {
+				return err
+			}
+		}
+		return bookkeep(sess)
+	}, options.Transaction().SetTimeOut(m.timeout))
+}
+
+func joinStatements(stmts []string) string {
+	script := ""
+	for _, stmt := range stmts {
+		script += strings.TrimRight(strings.TrimSpace(stmt), ";") + ";\n"
+	}
+	return script
+}
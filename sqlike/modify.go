@@ -3,9 +3,12 @@ package sqlike
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/codec"
 	sqldialect "github.com/RevenueMonster/sqlike/sql/dialect"
 	sqldriver "github.com/RevenueMonster/sqlike/sql/driver"
 	"github.com/RevenueMonster/sqlike/sql/expr"
@@ -15,7 +18,8 @@ import (
 	"github.com/RevenueMonster/sqlike/sqlike/options"
 )
 
-// ModifyOne :
+// ModifyOne : update all the columns of the record except the primary key. If the entity embeds `Snapshot`,
+// only the columns which are changed since it's decoded will be updated.
 func (tb *Table) ModifyOne(ctx context.Context, update interface{}, opts ...*options.ModifyOneOptions) error {
 	return modifyOne(
 		ctx,
@@ -23,15 +27,17 @@ func (tb *Table) ModifyOne(ctx context.Context, update interface{}, opts ...*opt
 		tb.name,
 		tb.pk,
 		tb.client.cache,
+		tb.codec,
 		tb.dialect,
 		tb.driver,
 		tb.logger,
+		tb.now(),
 		update,
 		opts,
 	)
 }
 
-func modifyOne(ctx context.Context, dbName, tbName, pk string, cache reflext.StructMapper, dialect sqldialect.Dialect, driver sqldriver.Driver, logger logs.Logger, update interface{}, opts []*options.ModifyOneOptions) error {
+func modifyOne(ctx context.Context, dbName, tbName, pk string, cache reflext.StructMapper, cdc codec.Codecer, dialect sqldialect.Dialect, driver sqldriver.Driver, logger logs.Logger, now time.Time, update interface{}, opts []*options.ModifyOneOptions) error {
 	v := reflext.ValueOf(update)
 	if !v.IsValid() {
 		return ErrInvalidInput
@@ -46,19 +52,50 @@ func modifyOne(ctx context.Context, dbName, tbName, pk string, cache reflext.Str
 		return ErrNilEntity
 	}
 
-	cdc := cache.CodecByType(t)
+	if err := beforeSave(ctx, v); err != nil {
+		return err
+	}
+
+	def := cache.CodecByType(t)
 	opt := new(options.ModifyOneOptions)
 	if len(opts) > 0 && opts[0] != nil {
 		opt = opts[0]
 	}
 
-	fields := skipColumns(cdc.Properties(), opt.Omits)
+	fields := skipColumns(def.Properties(), opt.Omits)
+	snap := snapshotOf(v)
+	dirty, err := dirtyFields(cache, cdc, v, fields, pk, snap)
+	if err != nil {
+		return err
+	}
+	// nothing to update, the timestamps shouldn't be touched as well
+	if snap != nil && len(dirty) == 0 {
+		if opt.ChangedFields != nil {
+			*opt.ChangedFields = []string{}
+		}
+		return nil
+	}
+	if err := autoTimestamps(cache, v, fields, now, false); err != nil {
+		return err
+	}
 	x := new(actions.UpdateActions)
 	x.Table = tbName
 
-	var pkv = [2]interface{}{}
+	var (
+		pkv     = [2]interface{}{}
+		version reflext.StructFielder
+		changes = make([]string, 0, len(fields))
+	)
 	for _, sf := range fields {
 		fv := cache.FieldByIndexesReadOnly(v, sf.Index())
+		if _, ok := sf.Tag().LookUp("version"); ok && version == nil {
+			if !isVersionKind(fv.Kind()) {
+				return fmt.Errorf("sqlike: version column %q must be an integer", sf.Name())
+			}
+			version = sf
+			x.Set(expr.ColumnValue(sf.Name(), expr.Increment(sf.Name(), 1)))
+			continue
+		}
 		if _, ok := sf.Tag().LookUp("primary_key"); ok {
 			if pkv[0] != nil {
 				x.Set(expr.ColumnValue(pkv[0].(string), pkv[1]))
@@ -72,6 +109,12 @@ func modifyOne(ctx context.Context, dbName, tbName, pk string, cache reflext.Str
 			pkv[1] = fv.Interface()
 			continue
 		}
+		if _, ok := sf.Tag().LookUp("auto_update_time"); !ok {
+			if snap != nil && !dirty[sf.Name()] {
+				continue
+			}
+			changes = append(changes, sf.Name())
+		}
 		x.Set(expr.ColumnValue(sf.Name(), fv.Interface()))
 	}
 
@@ -79,8 +122,18 @@ func modifyOne(ctx context.Context, dbName, tbName, pk string, cache reflext.Str
 		return errors.New("sqlike: missing primary key field")
 	}
 
-	x.Where(expr.Equal(pkv[0], pkv[1]))
+	if version != nil {
+		x.Where(
+			expr.Equal(pkv[0], pkv[1]),
+			expr.Equal(version.Name(), cache.FieldByIndexesReadOnly(v, version.Index()).Interface()),
+		)
+	} else {
+		x.Where(expr.Equal(pkv[0], pkv[1]))
+	}
 	x.Limit(1)
+	if !opt.NoResolution {
+		x.Conditions = withResolution(ctx, x.Conditions)
+	}
 	x.Table = tbName
 	x.Database = dbName
 
@@ -99,7 +152,17 @@ func modifyOne(ctx context.Context, dbName, tbName, pk string, cache reflext.Str
 	if err != nil {
 		return err
 	}
-	if !opt.NoStrict {
+	if version != nil {
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		// the record is either modified by others or not exists
+		if affected < 1 {
+			return ErrConcurrentModification
+		}
+		incrementVersion(cache.FieldByIndexes(v, version.Index()))
+	} else if !opt.NoStrict {
 		affected, err := result.RowsAffected()
 		if err != nil {
 			return err
@@ -108,5 +171,59 @@ func modifyOne(ctx context.Context, dbName, tbName, pk string, cache reflext.Str
 			return ErrNoRecordAffected
 		}
 	}
+	if opt.ChangedFields != nil {
+		*opt.ChangedFields = changes
+	}
+	// the record is in sync with database, so the next modification will only update the new changes
+	if snap != nil {
+		return snap.record(cache, cdc, reflext.Indirect(v), fields)
+	}
 	return nil
 }
+
+// dirtyFields : the fields which are changed since the snapshot is taken, the primary key, `version` and `auto_update_time` fields are excluded
+func dirtyFields(cache reflext.StructMapper, cdc codec.Codecer, v reflect.Value, fields []reflext.StructFielder, pk string, snap *Snapshot) (map[string]bool, error) {
+	if snap == nil {
+		return nil, nil
+	}
+	dirty := make(map[string]bool)
+	for _, sf := range fields {
+		tag := sf.Tag()
+		if _, ok := tag.LookUp("primary_key"); ok || sf.Name() == pk {
+			continue
+		}
+		if _, ok := tag.LookUp("version"); ok {
+			continue
+		}
+		if _, ok := tag.LookUp("auto_update_time"); ok {
+			continue
+		}
+		changed, err := snap.changed(cache, cdc, reflext.Indirect(v), sf)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			dirty[sf.Name()] = true
+		}
+	}
+	return dirty, nil
+}
+
+func isVersionKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+func incrementVersion(fv reflect.Value) {
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fv.SetInt(fv.Int() + 1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		fv.SetUint(fv.Uint() + 1)
+	}
+}
@@ -0,0 +1,28 @@
+package sqlike
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersion(t *testing.T) {
+	t.Run("isVersionKind", func(it *testing.T) {
+		require.True(it, isVersionKind(reflect.Int64))
+		require.True(it, isVersionKind(reflect.Uint32))
+		require.False(it, isVersionKind(reflect.String))
+		require.False(it, isVersionKind(reflect.Float64))
+	})
+
+	t.Run("incrementVersion", func(it *testing.T) {
+		var (
+			i int64  = 1
+			u uint16 = 9
+		)
+		incrementVersion(reflect.ValueOf(&i).Elem())
+		incrementVersion(reflect.ValueOf(&u).Elem())
+		require.Equal(it, int64(2), i)
+		require.Equal(it, uint16(10), u)
+	})
+}
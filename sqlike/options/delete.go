@@ -2,7 +2,9 @@ package options
 
 // DeleteOptions :
 type DeleteOptions struct {
-	Debug bool
+	Debug        bool
+	NoResolution bool
+	HardDelete   bool
 }
 
 // Delete :
@@ -15,3 +17,15 @@ func (opt *DeleteOptions) SetDebug(debug bool) *DeleteOptions {
 	opt.Debug = debug
 	return opt
 }
+
+// SetNoResolution : skip the resolution scopes injected into the context
+func (opt *DeleteOptions) SetNoResolution(noResolution bool) *DeleteOptions {
+	opt.NoResolution = noResolution
+	return opt
+}
+
+// SetHardDelete : physically delete the records even the table has soft delete column
+func (opt *DeleteOptions) SetHardDelete(hardDelete bool) *DeleteOptions {
+	opt.HardDelete = hardDelete
+	return opt
+}
@@ -15,3 +15,15 @@ func (opt *DeleteOneOptions) SetDebug(debug bool) *DeleteOneOptions {
 	opt.Debug = debug
 	return opt
 }
+
+// SetNoResolution : skip the resolution scopes injected into the context
+func (opt *DeleteOneOptions) SetNoResolution(noResolution bool) *DeleteOneOptions {
+	opt.NoResolution = noResolution
+	return opt
+}
+
+// SetHardDelete : physically delete the record even the table has soft delete column
+func (opt *DeleteOneOptions) SetHardDelete(hardDelete bool) *DeleteOneOptions {
+	opt.HardDelete = hardDelete
+	return opt
+}
@@ -18,4 +18,14 @@ func TestDeleteOptions(t *testing.T) {
 		opt.SetDebug(false)
 		require.False(t, opt.Debug)
 	}
+
+	{
+		opt.SetNoResolution(true)
+		require.True(t, opt.NoResolution)
+	}
+
+	{
+		opt.SetHardDelete(true)
+		require.True(t, opt.HardDelete)
+	}
 }
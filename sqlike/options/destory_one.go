@@ -15,3 +15,15 @@ func (opt *DestroyOneOptions) SetDebug(debug bool) *DestroyOneOptions {
 	opt.Debug = debug
 	return opt
 }
+
+// SetNoResolution : skip the resolution scopes injected into the context
+func (opt *DestroyOneOptions) SetNoResolution(noResolution bool) *DestroyOneOptions {
+	opt.NoResolution = noResolution
+	return opt
+}
+
+// SetHardDelete : physically delete the record even the table has soft delete column
+func (opt *DestroyOneOptions) SetHardDelete(hardDelete bool) *DestroyOneOptions {
+	opt.HardDelete = hardDelete
+	return opt
+}
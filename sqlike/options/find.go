@@ -5,8 +5,12 @@ type FindOptions struct {
 	OmitFields   []string
 	NoLimit      bool
 	LockMode     LockMode
+	LockOf       []string
+	LockWait     LockWaitPolicy
 	Debug        bool
 	NoResolution bool
+	WithDeleted  bool
+	Preload      []string
 }
 
 // Find :
@@ -38,8 +42,37 @@ func (opt *FindOptions) SetLockMode(lock LockMode) *FindOptions {
 	return opt
 }
 
+// SetLockOf : lock only the rows from the selected tables, the table can be an alias
+func (opt *FindOptions) SetLockOf(tables ...string) *FindOptions {
+	opt.LockOf = tables
+	return opt
+}
+
+// SetLockWait : set `NoWait` to fail immediately or `SkipLocked` to skip the rows which are locked
+func (opt *FindOptions) SetLockWait(wait LockWaitPolicy) *FindOptions {
+	opt.LockWait = wait
+	return opt
+}
+
+// Lock :
+func (opt *FindOptions) Lock() Lock {
+	return Lock{Mode: opt.LockMode, Of: opt.LockOf, Wait: opt.LockWait}
+}
+
 // SetNoResolution :
 func (opt *FindOptions) SetNoResolution(noResolution bool) *FindOptions {
 	opt.NoResolution = noResolution
 	return opt
 }
+
+// SetWithDeleted : include the soft deleted records
+func (opt *FindOptions) SetWithDeleted(withDeleted bool) *FindOptions {
+	opt.WithDeleted = withDeleted
+	return opt
+}
+
+// SetPreload : eager load the relationship fields, eg. `Orders` or nested field `Orders.Items`
+func (opt *FindOptions) SetPreload(fields ...string) *FindOptions {
+	opt.Preload = fields
+	return opt
+}
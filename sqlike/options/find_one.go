@@ -28,8 +28,32 @@ func (opt *FindOneOptions) SetLockMode(lock LockMode) *FindOneOptions {
 	return opt
 }
 
+// SetLockOf : lock only the rows from the selected tables, the table can be an alias
+func (opt *FindOneOptions) SetLockOf(tables ...string) *FindOneOptions {
+	opt.LockOf = tables
+	return opt
+}
+
+// SetLockWait : set `NoWait` to fail immediately or `SkipLocked` to skip the rows which are locked
+func (opt *FindOneOptions) SetLockWait(wait LockWaitPolicy) *FindOneOptions {
+	opt.LockWait = wait
+	return opt
+}
+
 // SetNoResolution :
 func (opt *FindOneOptions) SetNoResolution(noResolution bool) *FindOneOptions {
 	opt.NoResolution = noResolution
 	return opt
 }
+
+// SetWithDeleted : include the soft deleted records
+func (opt *FindOneOptions) SetWithDeleted(withDeleted bool) *FindOneOptions {
+	opt.WithDeleted = withDeleted
+	return opt
+}
+
+// SetPreload : eager load the relationship fields, eg. `Orders` or nested field `Orders.Items`
+func (opt *FindOneOptions) SetPreload(fields ...string) *FindOneOptions {
+	opt.Preload = fields
+	return opt
+}
@@ -57,4 +57,31 @@ func TestFindOne(t *testing.T) {
 			require.Equal(it, LockMode(0), ot.LockMode)
 		}
 	})
+	t.Run("SetLockOf", func(it *testing.T) {
+		opt.SetLockOf("A", "b")
+		require.Equal(it, []string{"A", "b"}, opt.LockOf)
+	})
+
+	t.Run("SetLockWait", func(it *testing.T) {
+		{
+			opt.SetLockWait(SkipLocked)
+			require.Equal(it, SkipLocked, opt.LockWait)
+		}
+
+		{
+			opt.SetLockWait(NoWait)
+			require.Equal(it, NoWait, opt.LockWait)
+		}
+
+		{
+			// default wait
+			ot := FindOne()
+			require.Equal(it, Wait, ot.LockWait)
+		}
+	})
+
+	t.Run("SetPreload", func(it *testing.T) {
+		opt.SetPreload("Profile")
+		require.Equal(it, []string{"Profile"}, opt.Preload)
+	})
 }
@@ -57,4 +57,39 @@ func TestFind(t *testing.T) {
 			require.Equal(it, LockMode(0), ot.LockMode)
 		}
 	})
+	t.Run("SetLockOf", func(it *testing.T) {
+		opt.SetLockOf("A", "b")
+		require.Equal(it, []string{"A", "b"}, opt.LockOf)
+	})
+
+	t.Run("SetLockWait", func(it *testing.T) {
+		{
+			opt.SetLockWait(SkipLocked)
+			require.Equal(it, SkipLocked, opt.LockWait)
+		}
+
+		{
+			opt.SetLockWait(NoWait)
+			require.Equal(it, NoWait, opt.LockWait)
+		}
+
+		{
+			// default wait
+			ot := Find()
+			require.Equal(it, Wait, ot.LockWait)
+		}
+	})
+
+	t.Run("SetWithDeleted", func(it *testing.T) {
+		opt.SetWithDeleted(true)
+		require.True(it, opt.WithDeleted)
+
+		opt.SetWithDeleted(false)
+		require.False(it, opt.WithDeleted)
+	})
+
+	t.Run("SetPreload", func(it *testing.T) {
+		opt.SetPreload("Orders", "Orders.Items")
+		require.Equal(it, []string{"Orders", "Orders.Items"}, opt.Preload)
+	})
 }
@@ -12,9 +12,16 @@ const (
 
 // InsertOptions :
 type InsertOptions struct {
-	Mode  insertMode
-	Omits util.StringSlice
-	Debug bool
+	Mode       insertMode
+	Omits      util.StringSlice
+	OnConflict []interface{}
+	Keeps      util.StringSlice
+	Debug      bool
+
+	// batch insert, only applicable on `Insert`
+	BatchSize        int
+	Concurrency      int
+	BatchTransaction bool
 }
 
 // Insert :
@@ -40,7 +47,36 @@ func (opt *InsertOptions) SetOmitFields(fields ...string) *InsertOptions {
 	return opt
 }
 
-// // SetOnConflict :
-// func (opt *InsertOptions) SetOnConflict(src []interface{}) *InsertOptions {
-// 	return opt
-// }
+// SetOnConflict : set the columns to update with inserted value, or `primitive.KV` to update with expression, when the record is conflicted.
+// All the columns will be updated if it's empty.
+func (opt *InsertOptions) SetOnConflict(values ...interface{}) *InsertOptions {
+	opt.Mode = InsertOnDuplicate
+	opt.OnConflict = values
+	return opt
+}
+
+// SetKeepOnConflict : keep the existing value of the columns when the record is conflicted
+func (opt *InsertOptions) SetKeepOnConflict(fields ...string) *InsertOptions {
+	opt.Mode = InsertOnDuplicate
+	opt.Keeps = fields
+	return opt
+}
+
+// SetBatchSize : split the records into multiple insert statements with maximum `size` records each,
+// it's to prevent the statement from exceeding `max_allowed_packet` or placeholder limit.
+func (opt *InsertOptions) SetBatchSize(size int) *InsertOptions {
+	opt.BatchSize = size
+	return opt
+}
+
+// SetConcurrency : the number of batches to insert concurrently, the default is 1 (sequential)
+func (opt *InsertOptions) SetConcurrency(concurrency int) *InsertOptions {
+	opt.Concurrency = concurrency
+	return opt
+}
+
+// SetBatchTransaction : insert each batch in its own transaction, it's ignored when it's already in a transaction
+func (opt *InsertOptions) SetBatchTransaction(tx bool) *InsertOptions {
+	opt.BatchTransaction = tx
+	return opt
+}
@@ -28,7 +28,17 @@ func (opt *InsertOneOptions) SetOmitFields(fields ...string) *InsertOneOptions {
 	return opt
 }
 
-// // SetOnConflict :
-// func (opt *InsertOneOptions) SetOnConflict(src []interface{}) *InsertOneOptions {
-// 	return opt
-// }
+// SetOnConflict : set the columns to update with inserted value, or `primitive.KV` to update with expression, when the record is conflicted.
+// All the columns will be updated if it's empty.
+func (opt *InsertOneOptions) SetOnConflict(values ...interface{}) *InsertOneOptions {
+	opt.Mode = InsertOnDuplicate
+	opt.OnConflict = values
+	return opt
+}
+
+// SetKeepOnConflict : keep the existing value of the columns when the record is conflicted
+func (opt *InsertOneOptions) SetKeepOnConflict(fields ...string) *InsertOneOptions {
+	opt.Mode = InsertOnDuplicate
+	opt.Keeps = fields
+	return opt
+}
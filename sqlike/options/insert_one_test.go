@@ -44,4 +44,15 @@ func TestInsertOneOptions(t *testing.T) {
 		require.ElementsMatch(it, []string{"test", "__c__"}, opt.Omits)
 	})
 
+	t.Run("SetOnConflict", func(it *testing.T) {
+		ot := InsertOne().SetOnConflict("A")
+		require.Equal(it, InsertOnDuplicate, ot.Mode)
+		require.Equal(it, []interface{}{"A"}, ot.OnConflict)
+	})
+
+	t.Run("SetKeepOnConflict", func(it *testing.T) {
+		ot := InsertOne().SetKeepOnConflict("A")
+		require.Equal(it, InsertOnDuplicate, ot.Mode)
+		require.ElementsMatch(it, []string{"A"}, ot.Keeps)
+	})
 }
@@ -44,4 +44,33 @@ func TestInsertOptions(t *testing.T) {
 		require.ElementsMatch(it, []string{"test", "__c__"}, opt.Omits)
 	})
 
+	t.Run("SetOnConflict", func(it *testing.T) {
+		ot := Insert().SetOnConflict("A", "B")
+		require.Equal(it, InsertOnDuplicate, ot.Mode)
+		require.Equal(it, []interface{}{"A", "B"}, ot.OnConflict)
+	})
+
+	t.Run("SetKeepOnConflict", func(it *testing.T) {
+		ot := Insert().SetKeepOnConflict("A")
+		require.Equal(it, InsertOnDuplicate, ot.Mode)
+		require.ElementsMatch(it, []string{"A"}, ot.Keeps)
+	})
+
+	t.Run("SetBatchSize", func(it *testing.T) {
+		opt.SetBatchSize(500)
+		require.Equal(it, 500, opt.BatchSize)
+	})
+
+	t.Run("SetConcurrency", func(it *testing.T) {
+		opt.SetConcurrency(4)
+		require.Equal(it, 4, opt.Concurrency)
+	})
+
+	t.Run("SetBatchTransaction", func(it *testing.T) {
+		opt.SetBatchTransaction(true)
+		require.True(it, opt.BatchTransaction)
+
+		opt.SetBatchTransaction(false)
+		require.False(it, opt.BatchTransaction)
+	})
 }
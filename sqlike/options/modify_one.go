@@ -2,9 +2,12 @@ package options
 
 // ModifyOneOptions :
 type ModifyOneOptions struct {
-	Omits    []string
-	Debug    bool
-	NoStrict bool
+	Omits        []string
+	Debug        bool
+	NoStrict     bool
+	NoResolution bool
+	// ChangedFields : the names of the updated columns will be reported to it, excluding the `version` and `auto_update_time` columns
+	ChangedFields *[]string
 }
 
 // ModifyOne :
@@ -29,3 +32,15 @@ func (opt *ModifyOneOptions) SetStrict(strict bool) *ModifyOneOptions {
 	opt.NoStrict = !strict
 	return opt
 }
+
+// SetNoResolution : skip the resolution scopes injected into the context
+func (opt *ModifyOneOptions) SetNoResolution(noResolution bool) *ModifyOneOptions {
+	opt.NoResolution = noResolution
+	return opt
+}
+
+// SetChangedFields : report the names of the updated columns, it will be empty if nothing is changed since the entity is decoded
+func (opt *ModifyOneOptions) SetChangedFields(fields *[]string) *ModifyOneOptions {
+	opt.ChangedFields = fields
+	return opt
+}
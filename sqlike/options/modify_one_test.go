@@ -33,4 +33,21 @@ func TestModifyOneOptions(t *testing.T) {
 		opt.SetStrict(false)
 		require.True(it, opt.NoStrict)
 	})
+
+	t.Run("SetNoResolution", func(it *testing.T) {
+		opt.SetNoResolution(true)
+		require.True(it, opt.NoResolution)
+
+		opt.SetNoResolution(false)
+		require.False(it, opt.NoResolution)
+	})
+
+	t.Run("SetChangedFields", func(it *testing.T) {
+		var fields []string
+		opt.SetChangedFields(&fields)
+		require.Same(it, &fields, opt.ChangedFields)
+
+		opt.SetChangedFields(nil)
+		require.Nil(it, opt.ChangedFields)
+	})
 }
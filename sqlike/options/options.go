@@ -8,4 +8,22 @@ const (
 	NoLock LockMode = iota
 	LockForUpdate
 	LockForRead
+	LockForShare
 )
+
+// LockWaitPolicy : the behaviour when the selected rows are locked by other transaction
+type LockWaitPolicy int
+
+// Lock wait policies :
+const (
+	Wait LockWaitPolicy = iota
+	NoWait
+	SkipLocked
+)
+
+// Lock : locking clause of select statement, eg. `FOR UPDATE OF table SKIP LOCKED`
+type Lock struct {
+	Mode LockMode
+	Of   []string
+	Wait LockWaitPolicy
+}
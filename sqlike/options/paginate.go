@@ -15,3 +15,21 @@ func (opt *PaginateOptions) SetDebug(debug bool) *PaginateOptions {
 	opt.Debug = debug
 	return opt
 }
+
+// SetNoResolution : skip the resolution scopes injected into the context
+func (opt *PaginateOptions) SetNoResolution(noResolution bool) *PaginateOptions {
+	opt.NoResolution = noResolution
+	return opt
+}
+
+// SetWithDeleted : include the soft deleted records
+func (opt *PaginateOptions) SetWithDeleted(withDeleted bool) *PaginateOptions {
+	opt.WithDeleted = withDeleted
+	return opt
+}
+
+// SetPreload : eager load the relationship fields of every page, eg. `Orders` or nested field `Orders.Items`
+func (opt *PaginateOptions) SetPreload(fields ...string) *PaginateOptions {
+	opt.Preload = fields
+	return opt
+}
@@ -33,6 +33,7 @@ type TransactionOptions struct {
 	Duration       time.Duration
 	IsolationLevel IsolationLevel
 	ReadOnly       bool
+	Retry          *RetryOptions
 }
 
 // SetTimeOut :
@@ -52,3 +53,50 @@ func (opts *TransactionOptions) SetReadOnly(readOnly bool) *TransactionOptions {
 	opts.ReadOnly = readOnly
 	return opts
 }
+
+// SetRetry : retry the transaction when the error is retryable, eg. deadlock and lock wait timeout
+func (opts *TransactionOptions) SetRetry(retry *RetryOptions) *TransactionOptions {
+	opts.Retry = retry
+	return opts
+}
+
+// DefaultRetryAttempts : the maximum number of attempts when `MaxAttempts` is not set
+const DefaultRetryAttempts = 3
+
+// Retry : by default the transaction is attempted up to 3 times, with 50ms backoff doubled up to 1s and 20% jitter
+func Retry() *RetryOptions {
+	return &RetryOptions{
+		MaxAttempts: DefaultRetryAttempts,
+		Backoff:     50 * time.Millisecond,
+		MaxBackoff:  time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// RetryOptions : the delay of retry is doubled on every attempt, eg. `Backoff`, `Backoff * 2`, `Backoff * 4` and so on,
+// until it reaches `MaxBackoff`, and it will be reduced randomly up to the fraction of `Jitter`.
+type RetryOptions struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+	Jitter      float64
+}
+
+// SetMaxAttempts : the maximum number of attempts including the first attempt, `DefaultRetryAttempts` is used if it's less than 1
+func (opts *RetryOptions) SetMaxAttempts(attempts int) *RetryOptions {
+	opts.MaxAttempts = attempts
+	return opts
+}
+
+// SetBackoff : the delay of the first retry, and the maximum delay of retry (zero means unlimited)
+func (opts *RetryOptions) SetBackoff(backoff, maxBackoff time.Duration) *RetryOptions {
+	opts.Backoff = backoff
+	opts.MaxBackoff = maxBackoff
+	return opts
+}
+
+// SetJitter : the fraction of the delay to be randomized, it should be between 0 and 1
+func (opts *RetryOptions) SetJitter(jitter float64) *RetryOptions {
+	opts.Jitter = jitter
+	return opts
+}
@@ -63,4 +63,30 @@ func TestTransactionOptions(t *testing.T) {
 		opt.SetIsolationLevel(sql.LevelLinearizable)
 		require.Equal(it, sql.LevelLinearizable, opt.IsolationLevel)
 	})
+
+	t.Run("SetRetry", func(it *testing.T) {
+		require.Equal(it, &RetryOptions{
+			MaxAttempts: DefaultRetryAttempts,
+			Backoff:     50 * time.Millisecond,
+			MaxBackoff:  time.Second,
+			Jitter:      0.2,
+		}, Retry())
+
+		retry := Retry().
+			SetMaxAttempts(3).
+			SetBackoff(10*time.Millisecond, time.Second).
+			SetJitter(0.5)
+		require.Equal(it, &RetryOptions{
+			MaxAttempts: 3,
+			Backoff:     10 * time.Millisecond,
+			MaxBackoff:  time.Second,
+			Jitter:      0.5,
+		}, retry)
+
+		opt.SetRetry(retry)
+		require.Same(it, retry, opt.Retry)
+
+		opt.SetRetry(nil)
+		require.Nil(it, opt.Retry)
+	})
 }
@@ -2,7 +2,8 @@ package options
 
 // UpdateOptions :
 type UpdateOptions struct {
-	Debug bool
+	Debug        bool
+	NoResolution bool
 }
 
 // Update :
@@ -15,3 +16,9 @@ func (opt *UpdateOptions) SetDebug(debug bool) *UpdateOptions {
 	opt.Debug = debug
 	return opt
 }
+
+// SetNoResolution : skip the resolution scopes injected into the context
+func (opt *UpdateOptions) SetNoResolution(noResolution bool) *UpdateOptions {
+	opt.NoResolution = noResolution
+	return opt
+}
@@ -15,3 +15,9 @@ func (opt *UpdateOneOptions) SetDebug(debug bool) *UpdateOneOptions {
 	opt.Debug = debug
 	return opt
 }
+
+// SetNoResolution : skip the resolution scopes injected into the context
+func (opt *UpdateOneOptions) SetNoResolution(noResolution bool) *UpdateOneOptions {
+	opt.NoResolution = noResolution
+	return opt
+}
@@ -18,4 +18,9 @@ func TestUpdateOptions(t *testing.T) {
 		opt.SetDebug(false)
 		require.False(t, opt.Debug)
 	}
+
+	{
+		opt.SetNoResolution(true)
+		require.True(t, opt.NoResolution)
+	}
 }
@@ -3,6 +3,8 @@ package sqlike
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
 
 	"github.com/RevenueMonster/sqlike/reflext"
 	"github.com/RevenueMonster/sqlike/sql/expr"
@@ -47,6 +49,7 @@ func (tb *Table) Paginate(ctx context.Context, act actions.PaginateStatement, op
 	if x.Count == 0 {
 		x.Count = 100
 	}
+	tb.excludeDeleted(&x.FindActions, &opt.FindOptions)
 	return &Paginator{
 		ctx:    ctx,
 		table:  tb,
@@ -65,20 +68,58 @@ type Paginator struct {
 	action actions.FindActions
 	option *options.FindOptions
 	err    error
+
+	// the page is before the cursor, the records are queried in reverse order
+	backward bool
+	// the record of the cursor is excluded from the page
+	exclusive bool
+	// there is no more record for `Next`
+	done bool
+}
+
+// PageInfo : the metadata of the page, the cursors are empty if the cursor key of client is not set.
+// `HasPrev` of forward page and `HasNext` of backward page are true when the page is started from a cursor,
+// as it requires another query to know whether the record exists in the opposite direction.
+type PageInfo struct {
+	HasNext bool
+	HasPrev bool
+	// StartCursor : pass it to `PrevCursor` to get the previous page, the first record is excluded
+	StartCursor Cursor
+	// EndCursor : pass it to `NextCursor` to get the next page, the last record is excluded
+	EndCursor Cursor
+}
+
+// NextCursor : the next page will start from the record of the cursor. The cursor is either the primary key of the record,
+// which requires a lookup query to get the values of the sort fields, or the `Cursor` created by `CursorOf` or `PageInfo`.
+func (pg *Paginator) NextCursor(ctx context.Context, cursor interface{}) error {
+	return pg.seek(ctx, cursor, false)
 }
 
-// NextCursor :
-func (pg *Paginator) NextCursor(ctx context.Context, cursor interface{}) (err error) {
+// PrevCursor : the previous page will end at the record of the cursor, the cursor is same as `NextCursor`.
+func (pg *Paginator) PrevCursor(ctx context.Context, cursor interface{}) error {
+	return pg.seek(ctx, cursor, true)
+}
+
+func (pg *Paginator) seek(ctx context.Context, cursor interface{}, backward bool) error {
 	if pg.err != nil {
 		return pg.err
 	}
 	if cursor == nil || reflext.IsZero(reflext.ValueOf(cursor)) {
 		return ErrInvalidCursor
 	}
+	if c, ok := cursor.(Cursor); ok {
+		payload, values, err := pg.parseCursor(c)
+		if err != nil {
+			return err
+		}
+		pg.values, pg.backward, pg.exclusive, pg.done = values, backward, payload.Exclusive, false
+		return nil
+	}
 	fa := actions.FindOne().Select(pg.fields...).Where(
 		expr.Equal(pg.table.pk, cursor),
 	).(*actions.FindOneActions)
 	fa.Limit(1)
+	pg.table.excludeDeleted(&fa.FindActions, pg.option)
 	result := find(
 		ctx,
 		pg.table.dbName,
@@ -89,13 +130,18 @@ func (pg *Paginator) NextCursor(ctx context.Context, cursor interface{}) (err er
 		pg.table.dialect,
 		pg.table.logger,
 		&fa.FindActions,
-		&options.FindOptions{Debug: pg.option.Debug},
-		options.NoLock,
+		&options.FindOptions{Debug: pg.option.Debug, NoResolution: pg.option.NoResolution, WithDeleted: pg.option.WithDeleted},
+		options.Lock{},
 	)
 	// prevent memory leak
 	defer result.Close()
-	pg.values, err = result.nextValues()
-	return
+	values, err := result.nextValues()
+	if err != nil {
+		pg.values = nil
+		return err
+	}
+	pg.values, pg.backward, pg.exclusive, pg.done = values, backward, false, false
+	return nil
 }
 
 // All :
@@ -103,8 +149,116 @@ func (pg *Paginator) All(results interface{}) error {
 	if pg.err != nil {
 		return pg.err
 	}
+	if err := pg.find(pg.ctx, pg.buildAction(), results); err != nil {
+		return err
+	}
+	if pg.backward {
+		reverseSlice(reflext.Indirect(reflext.ValueOf(results)))
+	}
+	return nil
+}
+
+// Page : same as `All`, but one more record is queried to determine whether there is next page (or previous page when it's `PrevCursor`)
+func (pg *Paginator) Page(results interface{}) (*PageInfo, error) {
+	if pg.err != nil {
+		return nil, pg.err
+	}
+	action := pg.buildAction()
+	action.Count = pg.action.Count + 1
+	if err := pg.find(pg.ctx, action, results); err != nil {
+		return nil, err
+	}
+	return pg.pageInfo(reflext.Indirect(reflext.ValueOf(results)))
+}
+
+// Next : query the page into results, and move to the page after the last record, (or before the first record when it's `PrevCursor`).
+// It returns false when there is no more record, the context is cancelled or error occurred, the error can be checked using `Err`.
+//
+//	for pg.Next(ctx, &users) {
+//		// process the users
+//	}
+//	if err := pg.Err(); err != nil {
+//		return err
+//	}
+func (pg *Paginator) Next(ctx context.Context, results interface{}) bool {
+	if pg.err != nil || pg.done {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		pg.err = err
+		return false
+	}
+	action := pg.buildAction()
+	action.Count = pg.action.Count + 1
+	if err := pg.find(ctx, action, results); err != nil {
+		pg.err = err
+		return false
+	}
+	ok, err := pg.advance(reflext.Indirect(reflext.ValueOf(results)))
+	if err != nil {
+		pg.err = err
+		return false
+	}
+	return ok
+}
+
+// Err : the error occurred in `Next`, it's nil when the iteration is completed
+func (pg *Paginator) Err() error {
+	return pg.err
+}
+
+// Each : iterate all the pages using `Next`, fn must be `func(page []T) error` and every page is decoded into a new slice of `T`.
+// The iteration is stopped when fn returns error or the context is cancelled.
+//
+//	err := pg.Each(ctx, func(users []User) error {
+//		// process the users
+//		return nil
+//	})
+func (pg *Paginator) Each(ctx context.Context, fn interface{}) error {
+	ft := reflect.TypeOf(fn)
+	if ft == nil || ft.Kind() != reflect.Func || ft.NumIn() != 1 || ft.In(0).Kind() != reflect.Slice ||
+		ft.NumOut() != 1 || ft.Out(0) != reflect.TypeOf((*error)(nil)).Elem() {
+		return fmt.Errorf("sqlike: Each expects func([]T) error, but got %T", fn)
+	}
+	fv := reflect.ValueOf(fn)
+	for {
+		results := reflect.New(ft.In(0))
+		if !pg.Next(ctx, results.Interface()) {
+			break
+		}
+		if err, _ := fv.Call([]reflect.Value{results.Elem()})[0].Interface().(error); err != nil {
+			return err
+		}
+	}
+	return pg.Err()
+}
+
+// advance : remove the extra record from the results, and move the cursor to the boundary record of the page
+func (pg *Paginator) advance(v reflect.Value) (bool, error) {
+	more := v.Len() > int(pg.action.Count)
+	if more {
+		v.Set(v.Slice(0, int(pg.action.Count)))
+	}
+	if v.Len() < 1 {
+		pg.done = true
+		return false, nil
+	}
+	boundary := v.Index(v.Len() - 1)
+	if pg.backward {
+		reverseSlice(v)
+		boundary = v.Index(0)
+	}
+	values, err := pg.valuesOf(boundary.Interface())
+	if err != nil {
+		return false, err
+	}
+	pg.values, pg.exclusive, pg.done = values, true, !more
+	return true, nil
+}
+
+func (pg *Paginator) find(ctx context.Context, action *actions.FindActions, results interface{}) error {
 	result := find(
-		pg.ctx,
+		ctx,
 		pg.table.dbName,
 		pg.table.name,
 		pg.table.client.cache,
@@ -112,50 +266,109 @@ func (pg *Paginator) All(results interface{}) error {
 		pg.table.driver,
 		pg.table.dialect,
 		pg.table.logger,
-		pg.buildAction(),
+		action,
 		pg.option,
-		options.NoLock,
+		options.Lock{},
 	)
+	if len(pg.option.Preload) > 0 {
+		result.preload = func(v reflect.Value) error {
+			return pg.table.preload(ctx, v, pg.option.Preload, pg.option)
+		}
+	}
 	return result.All(results)
 }
 
+// pageInfo : remove the extra record from the results, and restore the order of the results if it's backward
+func (pg *Paginator) pageInfo(v reflect.Value) (*PageInfo, error) {
+	info := new(PageInfo)
+	more := v.Len() > int(pg.action.Count)
+	if more {
+		v.Set(v.Slice(0, int(pg.action.Count)))
+	}
+	started := len(pg.values) > 0
+	if pg.backward {
+		reverseSlice(v)
+		info.HasNext, info.HasPrev = started, more
+	} else {
+		info.HasNext, info.HasPrev = more, started
+	}
+	if v.Len() < 1 {
+		return info, nil
+	}
+	if _, err := pg.cursorKey(); err != nil {
+		return info, nil
+	}
+	var err error
+	info.StartCursor, err = pg.cursorOf(v.Index(0).Interface(), true)
+	if err != nil {
+		return nil, err
+	}
+	info.EndCursor, err = pg.cursorOf(v.Index(v.Len()-1).Interface(), true)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// buildAction : the sorting is reversed when it's backward, so the records before the cursor are queried
 func (pg *Paginator) buildAction() *actions.FindActions {
 	action := pg.action
+	if pg.backward {
+		sorts := make([]interface{}, len(action.Sorts))
+		for i, sf := range action.Sorts {
+			x := sf.(primitive.Sort)
+			if x.Order == primitive.Ascending {
+				x.Order = primitive.Descending
+			} else {
+				x.Order = primitive.Ascending
+			}
+			sorts[i] = x
+		}
+		action.Sorts = sorts
+	}
 	if len(pg.values) < 1 {
 		return &action
 	}
+	// seek using the lexicographic order of the sort fields, eg. `(a > va) OR (a = va AND b > vb) OR (a = va AND b = vb AND c >= vc)`,
+	// and only the last field (primary key) is inclusive when the cursor is not exclusive
 	length := len(pg.fields)
 	filters := make([]interface{}, 0, length)
-	fields := make([]interface{}, 0)
+	equals := make([]interface{}, 0, length)
 	for i, sf := range action.Sorts {
-		var v primitive.C
 		val := toString(pg.values[i])
 		x := sf.(primitive.Sort)
-		if i == length-1 {
-			if x.Order == primitive.Ascending {
-				fields = append(fields, expr.GreaterOrEqual(x.Field, val))
-			} else {
-				fields = append(fields, expr.LesserOrEqual(x.Field, val))
-			}
-			continue
-		}
-		if x.Order == primitive.Ascending {
-			filters = append(filters, expr.GreaterOrEqual(x.Field, val))
+		var v primitive.C
+		switch {
+		case x.Order == primitive.Ascending && (i < length-1 || pg.exclusive):
 			v = expr.GreaterThan(x.Field, val)
-		} else {
-			filters = append(filters, expr.LesserOrEqual(x.Field, val))
+		case x.Order == primitive.Ascending:
+			v = expr.GreaterOrEqual(x.Field, val)
+		case i < length-1 || pg.exclusive:
 			v = expr.LesserThan(x.Field, val)
+		default:
+			v = expr.LesserOrEqual(x.Field, val)
+		}
+		if len(equals) > 0 {
+			filters = append(filters, expr.And(append(equals[:len(equals):len(equals)], v)...))
+		} else {
+			filters = append(filters, v)
 		}
-		fields = append(fields, v)
+		equals = append(equals, expr.Equal(x.Field, val))
 	}
-	filters = append(filters, expr.Or(fields...))
 	if len(action.Conditions.Values) > 0 {
 		action.Conditions.Values = append(action.Conditions.Values, primitive.And)
 	}
-	action.Conditions.Values = append(action.Conditions.Values, expr.And(filters...))
+	action.Conditions.Values = append(action.Conditions.Values, expr.Or(filters...))
 	return &action
 }
 
+func reverseSlice(v reflect.Value) {
+	swap := reflect.Swapper(v.Interface())
+	for i, j := 0, v.Len()-1; i < j; i, j = i+1, j-1 {
+		swap(i, j)
+	}
+}
+
 func toString(v interface{}) interface{} {
 	switch vi := v.(type) {
 	case []byte:
@@ -2,10 +2,18 @@ package sqlike
 
 import (
 	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
 	"testing"
 
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/codec"
+	"github.com/RevenueMonster/sqlike/sql/dialect/mysql"
 	"github.com/RevenueMonster/sqlike/sql/expr"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
 	"github.com/RevenueMonster/sqlike/sqlike/actions"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
 	"github.com/RevenueMonster/sqlike/sqlike/primitive"
 	"github.com/stretchr/testify/require"
 )
@@ -80,4 +88,240 @@ func TestPaginate(t *testing.T) {
 		}, pg.action.Sorts)
 	})
 
+	t.Run("lexicographic seek", func(ti *testing.T) {
+		pg, err = tb.Paginate(
+			ctx,
+			actions.Paginate().
+				Where(expr.Equal("D", 1)).
+				OrderBy(
+					expr.Asc("A"),
+					expr.Desc("B"),
+				).
+				Limit(10),
+		)
+		require.NoError(ti, err)
+		pg.values = []interface{}{"a", int64(2), int64(3)}
+
+		build := func() string {
+			ms := mysql.New()
+			stmt := sqlstmt.AcquireStmt(ms)
+			defer sqlstmt.ReleaseStmt(stmt)
+			act := pg.buildAction()
+			act.Database, act.Table = "db", "User"
+			require.NoError(ti, ms.Select(stmt, act, options.NoLock))
+			return stmt.String()
+		}
+
+		pg.exclusive = true
+		require.Equal(ti, "SELECT * FROM `db`.`User` WHERE `D` = ? AND (`A` > ? OR (`A` = ? AND `B` < ?) OR (`A` = ? AND `B` = ? AND `ID` < ?)) ORDER BY `A`,`B` DESC,`ID` DESC LIMIT 10;", build())
+
+		// the last field is inclusive, and the operators are flipped on backward
+		pg.exclusive, pg.backward = false, true
+		require.Equal(ti, "SELECT * FROM `db`.`User` WHERE `D` = ? AND (`A` < ? OR (`A` = ? AND `B` > ?) OR (`A` = ? AND `B` = ? AND `ID` >= ?)) ORDER BY `A` DESC,`B`,`ID` LIMIT 10;", build())
+	})
+}
+
+func TestPageInfo(t *testing.T) {
+	ctx := context.Background()
+	client := &Client{cache: reflext.DefaultMapper}
+	client.SetCursorKey([]byte("secret"))
+	tb := &Table{
+		dbName:  "db",
+		name:    "User",
+		pk:      "ID",
+		client:  client,
+		driver:  new(execDriver),
+		dialect: mysql.New(),
+		codec:   codec.DefaultRegistry,
+	}
+	paginate := func() *Paginator {
+		pg, err := tb.Paginate(ctx, actions.Paginate().OrderBy(expr.Desc("Age")).Limit(2))
+		require.NoError(t, err)
+		return pg
+	}
+	type user struct {
+		ID  int64
+		Age int
+	}
+	// the last clause is the filter of primary key
+	var lastFilter func(v interface{}) primitive.C
+	lastFilter = func(v interface{}) primitive.C {
+		switch vi := v.(type) {
+		case *actions.FindActions:
+			return lastFilter(vi.Conditions)
+		case primitive.Group:
+			for i := len(vi.Values) - 1; i >= 0; i-- {
+				if _, ok := vi.Values[i].(primitive.Raw); !ok {
+					return lastFilter(vi.Values[i])
+				}
+			}
+		case primitive.C:
+			return vi
+		}
+		return primitive.C{}
+	}
+
+	t.Run("first page", func(it *testing.T) {
+		pg := paginate()
+		users := []user{{1, 30}, {2, 20}, {3, 10}}
+		v := reflect.ValueOf(&users).Elem()
+		info, err := pg.pageInfo(v)
+		require.NoError(it, err)
+		require.Equal(it, []user{{1, 30}, {2, 20}}, users)
+		require.True(it, info.HasNext)
+		require.False(it, info.HasPrev)
+		require.NotEmpty(it, info.StartCursor)
+		require.NotEmpty(it, info.EndCursor)
+
+		// next page is started after the end cursor
+		require.NoError(it, pg.NextCursor(ctx, info.EndCursor))
+		act := pg.buildAction()
+		require.Equal(it, []interface{}{expr.Desc("Age"), expr.Desc("ID")}, act.Sorts)
+		require.Equal(it, expr.LesserThan("ID", int64(2)), lastFilter(act))
+
+		// previous page is ended before the start cursor, in reverse order
+		require.NoError(it, pg.PrevCursor(ctx, info.StartCursor))
+		act = pg.buildAction()
+		require.Equal(it, []interface{}{expr.Asc("Age"), expr.Asc("ID")}, act.Sorts)
+		require.Equal(it, expr.GreaterThan("ID", int64(1)), lastFilter(act))
+	})
+
+	t.Run("cursor of record is inclusive", func(it *testing.T) {
+		pg := paginate()
+		cursor, err := pg.CursorOf(user{5, 18})
+		require.NoError(it, err)
+		require.NoError(it, pg.PrevCursor(ctx, cursor))
+		require.Equal(it, expr.GreaterOrEqual("ID", int64(5)), lastFilter(pg.buildAction()))
+	})
+
+	t.Run("backward page", func(it *testing.T) {
+		pg := paginate()
+		cursor, err := pg.CursorOf(user{5, 18})
+		require.NoError(it, err)
+		require.NoError(it, pg.PrevCursor(ctx, cursor))
+
+		// queried in reverse order
+		users := []user{{4, 20}, {3, 25}}
+		info, err := pg.pageInfo(reflect.ValueOf(&users).Elem())
+		require.NoError(it, err)
+		require.Equal(it, []user{{3, 25}, {4, 20}}, users)
+		require.False(it, info.HasPrev)
+		require.True(it, info.HasNext)
+
+		users = []user{{4, 20}, {3, 25}, {2, 30}}
+		info, err = pg.pageInfo(reflect.ValueOf(&users).Elem())
+		require.NoError(it, err)
+		require.Equal(it, []user{{3, 25}, {4, 20}}, users)
+		require.True(it, info.HasPrev)
+	})
+
+	t.Run("empty page", func(it *testing.T) {
+		users := []user{}
+		info, err := paginate().pageInfo(reflect.ValueOf(&users).Elem())
+		require.NoError(it, err)
+		require.Equal(it, &PageInfo{}, info)
+	})
+}
+
+func TestPaginatorIterator(t *testing.T) {
+	type user struct {
+		ID  int64
+		Age int
+	}
+	tb := &Table{
+		dbName:  "db",
+		name:    "User",
+		pk:      "ID",
+		client:  &Client{cache: reflext.DefaultMapper},
+		driver:  new(execDriver),
+		dialect: mysql.New(),
+		codec:   codec.DefaultRegistry,
+	}
+	paginate := func() *Paginator {
+		pg, err := tb.Paginate(context.Background(), actions.Paginate().OrderBy(expr.Asc("Age")).Limit(2))
+		require.NoError(t, err)
+		return pg
+	}
+
+	t.Run("advance", func(it *testing.T) {
+		pg := paginate()
+		users := []user{{1, 10}, {2, 20}, {3, 30}}
+		ok, err := pg.advance(reflect.ValueOf(&users).Elem())
+		require.NoError(it, err)
+		require.True(it, ok)
+		require.Equal(it, []user{{1, 10}, {2, 20}}, users)
+		require.Equal(it, []interface{}{int64(20), int64(2)}, pg.values)
+		require.True(it, pg.exclusive)
+		require.False(it, pg.done)
+
+		// last page
+		users = []user{{3, 30}}
+		ok, err = pg.advance(reflect.ValueOf(&users).Elem())
+		require.NoError(it, err)
+		require.True(it, ok)
+		require.True(it, pg.done)
+		require.False(it, pg.Next(context.Background(), &users))
+		require.NoError(it, pg.Err())
+
+		users = []user{}
+		ok, err = paginate().advance(reflect.ValueOf(&users).Elem())
+		require.NoError(it, err)
+		require.False(it, ok)
+	})
+
+	t.Run("advance backward", func(it *testing.T) {
+		pg := paginate()
+		pg.backward = true
+		users := []user{{3, 30}, {2, 20}, {1, 10}}
+		ok, err := pg.advance(reflect.ValueOf(&users).Elem())
+		require.NoError(it, err)
+		require.True(it, ok)
+		require.Equal(it, []user{{2, 20}, {3, 30}}, users)
+		require.Equal(it, []interface{}{int64(20), int64(2)}, pg.values)
+	})
+
+	t.Run("cancelled context", func(it *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		pg := paginate()
+		require.Equal(it, context.Canceled, pg.Each(ctx, func(users []user) error {
+			it.Fatal("callback should not be invoked")
+			return nil
+		}))
+		users := []user{}
+		require.False(it, pg.Next(context.Background(), &users))
+	})
+
+	t.Run("Each", func(it *testing.T) {
+		type entity struct {
+			ID int64 `sqlike:"value"`
+		}
+		db, d := newTxDatabase(it)
+		db.pk = "value"
+		tb := db.Table("entity")
+		require.NoError(it, tb.Register(entity{}))
+		d.rows["FROM `db`.`entity`"] = []driver.Value{int64(1), int64(2), int64(3)}
+
+		pg, err := tb.Paginate(context.Background(), actions.Paginate().Limit(2))
+		require.NoError(it, err)
+		errStop := errors.New("stop")
+		pages := make([][]entity, 0)
+		require.Equal(it, errStop, pg.Each(context.Background(), func(page []entity) error {
+			pages = append(pages, page)
+			if len(pages) == 2 {
+				return errStop
+			}
+			return nil
+		}))
+		// every page is decoded into a new slice
+		require.Equal(it, [][]entity{{{1}, {2}}, {{1}, {2}}}, pages)
+		require.Contains(it, d.history()[1], "`value` > ?")
+	})
+
+	t.Run("invalid callback", func(it *testing.T) {
+		pg := paginate()
+		require.EqualError(it, pg.Each(context.Background(), func() error { return nil }), "sqlike: Each expects func([]T) error, but got func() error")
+		require.EqualError(it, pg.Each(context.Background(), func(user) error { return nil }), "sqlike: Each expects func([]T) error, but got func(sqlike.user) error")
+		require.EqualError(it, pg.Each(context.Background(), nil), "sqlike: Each expects func([]T) error, but got <nil>")
+	})
 }
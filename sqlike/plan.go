@@ -0,0 +1,658 @@
+package sqlike
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	sqldialect "github.com/RevenueMonster/sqlike/sql/dialect"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sql/util"
+	"github.com/RevenueMonster/sqlike/sqlike/columns"
+	"github.com/RevenueMonster/sqlike/sqlike/indexes"
+)
+
+type changeAction int
+
+// change actions :
+const (
+	ChangeAdd changeAction = iota + 1
+	ChangeModify
+	ChangeDrop
+	ChangeReorder
+)
+
+func (c changeAction) String() string {
+	switch c {
+	case ChangeModify:
+		return "MODIFY"
+	case ChangeDrop:
+		return "DROP"
+	case ChangeReorder:
+		return "REORDER"
+	default:
+		return "ADD"
+	}
+}
+
+// ColumnChange : the change of a column in migration plan
+type ColumnChange struct {
+	Action changeAction
+
+	// column name
+	Name string
+
+	// column data type in table, empty if it's a new column
+	OldType string
+
+	// column data type follows by the struct, empty if the column will be dropped
+	NewType string
+
+	// the changed attributes other than the data type and nullability, eg. DEFAULT, CHARACTER SET, COLLATE, COMMENT and EXTRA
+	Attributes []string
+
+	// whether the change may cause data loss, eg. drop column or narrow the data type
+	Destructive bool
+}
+
+// IndexChange : the change of an index in migration plan
+type IndexChange struct {
+	Action      changeAction
+	Name        string
+	Columns     []string
+	Destructive bool
+}
+
+// MigrationPlan : the changes and statements of the migration, nothing is executed
+type MigrationPlan struct {
+	Table string
+
+	// whether the table will be created
+	Create bool
+
+	// whether the plan is generated by unsafe migrate (columns will be dropped)
+	Unsafe bool
+
+	Columns     []ColumnChange
+	Indexes     []IndexChange
+	ForeignKeys []IndexChange
+
+	// the exact sql statement which will be executed on migrate
+	Statement string
+}
+
+// HasChanges : whether there is anything to migrate
+func (p *MigrationPlan) HasChanges() bool {
+	return p.Create || len(p.Columns) > 0 || len(p.Indexes) > 0 || len(p.ForeignKeys) > 0
+}
+
+// IsDestructive : whether any change of the plan may cause data loss
+func (p *MigrationPlan) IsDestructive() bool {
+	for _, c := range p.Columns {
+		if c.Destructive {
+			return true
+		}
+	}
+	for _, idx := range p.Indexes {
+		if idx.Destructive {
+			return true
+		}
+	}
+	for _, fk := range p.ForeignKeys {
+		if fk.Destructive {
+			return true
+		}
+	}
+	return false
+}
+
+// PlanMigrate : plan the migration without executing it, `Migrate` executes exactly the statement of the plan and nothing if the plan has no changes
+func (tb *Table) PlanMigrate(ctx context.Context, entity interface{}) (*MigrationPlan, error) {
+	return tb.planMigrate(ctx, entity, false)
+}
+
+// PlanUnsafeMigrate : plan the unsafe migration without executing it, `UnsafeMigrate` executes exactly the statement of the plan and nothing if the plan has no changes
+func (tb *Table) PlanUnsafeMigrate(ctx context.Context, entity interface{}) (*MigrationPlan, error) {
+	return tb.planMigrate(ctx, entity, true)
+}
+
+type plannedColumn struct {
+	name string
+	sf   reflext.StructFielder
+}
+
+func (pc plannedColumn) columnName(name string) string {
+	if pc.name != "" {
+		return pc.name
+	}
+	return name
+}
+
+// migrationDiff : the difference between the struct and the existing table, it's shared by `PlanMigrate` and `MigrateStatements`
+type migrationDiff struct {
+	plan    *MigrationPlan
+	fields  []reflext.StructFielder
+	columns []Column
+	idxs    []Index
+	hasPk   bool
+	fkAdds  []indexes.ForeignKey
+	fkDrops []string
+	// the existing foreign keys of the table
+	fks []indexes.ForeignKey
+}
+
+func (tb *Table) planMigrate(ctx context.Context, entity interface{}, unsafe bool) (*MigrationPlan, error) {
+	diff, err := tb.diffMigrate(ctx, entity, unsafe)
+	if err != nil {
+		return nil, err
+	}
+	stmts, err := tb.upStatements(diff)
+	if err != nil {
+		return nil, err
+	}
+	// the statements are in the same order as `Migrate` executes them
+	diff.plan.Statement = strings.Join(stmts, "")
+	return diff.plan, nil
+}
+
+func (tb *Table) diffMigrate(ctx context.Context, entity interface{}, unsafe bool) (*migrationDiff, error) {
+	v := reflext.ValueOf(entity)
+	if !v.IsValid() {
+		return nil, ErrInvalidInput
+	}
+
+	t := reflext.Deref(v.Type())
+	if !reflext.IsKind(t, reflect.Struct) {
+		return nil, ErrExpectedStruct
+	}
+
+	fields := skipColumns(tb.client.cache.CodecByType(t).Properties(), nil)
+	if len(fields) < 1 {
+		return nil, ErrEmptyFields
+	}
+
+	fks, err := indexes.ForeignKeys(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := new(MigrationPlan)
+	plan.Table = tb.name
+	plan.Unsafe = unsafe
+	diff := &migrationDiff{plan: plan, fields: fields}
+
+	if !tb.Exists(ctx) {
+		plan.Create = true
+		for _, pc := range plannedColumns(fields) {
+			col, err := tb.dialect.ColumnSchema(tb.client.DriverInfo, pc.sf)
+			if err != nil {
+				return nil, err
+			}
+			plan.Columns = append(plan.Columns, ColumnChange{
+				Action:  ChangeAdd,
+				Name:    pc.columnName(col.Name),
+				NewType: col.Type,
+			})
+		}
+		for _, fk := range fks {
+			plan.ForeignKeys = append(plan.ForeignKeys, IndexChange{
+				Action:  ChangeAdd,
+				Name:    fk.GetName(tb.name),
+				Columns: fk.Columns,
+			})
+		}
+		return diff, nil
+	}
+
+	columns, err := tb.ListColumns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	idxs, err := tb.ListIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hasPk, err := tb.hasPrimaryKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	diff.columns, diff.idxs, diff.hasPk = columns, idxs, hasPk
+
+	existing := make(map[string]Column, len(columns))
+	for _, col := range columns {
+		existing[col.Name] = col
+	}
+
+	planned := plannedColumns(fields)
+	names := make(util.StringSlice, 0, len(planned))
+	for _, pc := range planned {
+		col, err := tb.dialect.ColumnSchema(tb.client.DriverInfo, pc.sf)
+		if err != nil {
+			return nil, err
+		}
+		name := pc.columnName(col.Name)
+		names = append(names, name)
+		old, ok := existing[name]
+		if !ok {
+			plan.Columns = append(plan.Columns, ColumnChange{
+				Action:  ChangeAdd,
+				Name:    name,
+				NewType: col.Type,
+			})
+			continue
+		}
+		// sqlite doesn't support altering the column definition
+		if driver := tb.client.DriverName(); driver == "sqlite" || driver == "sqlite3" {
+			continue
+		}
+		col.Name = name
+		comment, hasComment := pc.sf.Tag().LookUp("comment")
+		if change, ok := modifyColumn(tb.client.DriverName(), old, col, comment, hasComment); ok {
+			plan.Columns = append(plan.Columns, change)
+		}
+	}
+
+	// only mysql will reorder the columns, the columns are reordered when the relative position of existing columns is different
+	current := make([]string, 0, len(columns))
+	for _, col := range columns {
+		if names.IndexOf(col.Name) > -1 {
+			current = append(current, col.Name)
+		}
+	}
+	expected := make([]string, 0, len(current))
+	for _, name := range names {
+		if _, ok := existing[name]; ok {
+			expected = append(expected, name)
+		}
+	}
+	for i := range expected {
+		if tb.client.DriverName() == "mysql" && expected[i] != current[i] {
+			plan.Columns = append(plan.Columns, ColumnChange{
+				Action:  ChangeReorder,
+				Name:    expected[i],
+				OldType: existing[expected[i]].Type,
+				NewType: existing[expected[i]].Type,
+			})
+		}
+	}
+
+	if unsafe {
+		for _, col := range columns {
+			if names.IndexOf(col.Name) > -1 {
+				continue
+			}
+			plan.Columns = append(plan.Columns, ColumnChange{
+				Action:      ChangeDrop,
+				Name:        col.Name,
+				OldType:     col.Type,
+				Destructive: true,
+			})
+		}
+	}
+
+	idxNames := util.StringSlice(indexNames(idxs))
+	for _, sf := range fields {
+		_, ok1 := sf.Tag().LookUp("unique_index")
+		_, ok2 := sf.Tag().LookUp("auto_increment")
+		if !ok1 && !ok2 {
+			continue
+		}
+		idx := indexes.Index{Columns: indexes.Columns(sf.Name())}
+		if idxNames.IndexOf(idx.GetName()) < 0 {
+			plan.Indexes = append(plan.Indexes, IndexChange{
+				Action:  ChangeAdd,
+				Name:    idx.GetName(),
+				Columns: []string{sf.Name()},
+			})
+		}
+	}
+	if !hasPk {
+		if pk := primaryKeyOf(fields, tb.pk); pk != "" {
+			plan.Indexes = append(plan.Indexes, IndexChange{
+				Action:  ChangeAdd,
+				Name:    "PRIMARY",
+				Columns: []string{pk},
+			})
+		}
+	}
+
+	if !tb.alterForeignKeys() {
+		return diff, nil
+	}
+	existingFks, err := tb.ListForeignKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	diff.fks = existingFks
+	diff.fkAdds, diff.fkDrops = diffForeignKeys(tb.name, fks, existingFks, unsafe)
+	for _, name := range diff.fkDrops {
+		plan.ForeignKeys = append(plan.ForeignKeys, IndexChange{
+			Action: ChangeDrop,
+			Name:   name,
+		})
+	}
+	for _, fk := range diff.fkAdds {
+		plan.ForeignKeys = append(plan.ForeignKeys, IndexChange{
+			Action:  ChangeAdd,
+			Name:    fk.GetName(tb.name),
+			Columns: fk.Columns,
+		})
+	}
+	return diff, nil
+}
+
+// upStatements : the statements to apply the diff, in the same order as `Migrate` executes them
+func (tb *Table) upStatements(diff *migrationDiff) ([]string, error) {
+	stmts := make([]string, 0, 3)
+	if err := tb.buildUpStatements(diff, func(stmt *sqlstmt.Statement) error {
+		stmts = append(stmts, fmt.Sprintf("%+v", stmt))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return stmts, nil
+}
+
+// buildUpStatements : build the statements to apply the diff and pass each of them to `fn`,
+// it's shared by `Migrate` and `PlanMigrate` so the plan is always what will be executed
+func (tb *Table) buildUpStatements(diff *migrationDiff, fn func(stmt *sqlstmt.Statement) error) error {
+	stmt := sqlstmt.AcquireStmt(tb.dialect)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	// the statement may be empty if the dialect doesn't support the operation
+	next := func() error {
+		defer stmt.Reset()
+		if stmt.String() == "" {
+			return nil
+		}
+		return fn(stmt)
+	}
+
+	plan := diff.plan
+	if plan.Create {
+		if err := tb.dialect.CreateTable(
+			stmt,
+			tb.dbName,
+			tb.name,
+			tb.pk,
+			tb.client.DriverInfo,
+			diff.fields,
+		); err != nil {
+			return err
+		}
+		return next()
+	}
+	if !plan.HasChanges() {
+		return nil
+	}
+
+	// foreign key must be dropped before the column is dropped
+	if len(diff.fkDrops) > 0 {
+		tb.dialect.DropForeignKeys(stmt, tb.dbName, tb.name, diff.fkDrops)
+		if err := next(); err != nil {
+			return err
+		}
+	}
+	if err := tb.dialect.AlterTable(
+		stmt,
+		tb.dbName, tb.name, tb.pk, diff.hasPk,
+		tb.client.DriverInfo,
+		diff.fields, columnNames(diff.columns), indexNames(diff.idxs), plan.Unsafe,
+	); err != nil {
+		return err
+	}
+	if err := next(); err != nil {
+		return err
+	}
+	if len(diff.fkAdds) > 0 {
+		tb.dialect.AddForeignKeys(stmt, tb.dbName, tb.name, diff.fkAdds)
+		return next()
+	}
+	return nil
+}
+
+// downStatements : the statements to revert the diff, the changes are reverted in reverse order
+func (tb *Table) downStatements(diff *migrationDiff) ([]string, error) {
+	stmt := sqlstmt.AcquireStmt(tb.dialect)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	plan := diff.plan
+	if plan.Create {
+		tb.dialect.DropTable(stmt, tb.dbName, tb.name, true)
+		return []string{fmt.Sprintf("%+v", stmt)}, nil
+	}
+	if !plan.HasChanges() {
+		return nil, nil
+	}
+
+	reverter, _ := tb.dialect.(sqldialect.MigrationReverter)
+	stmts := make([]string, 0)
+	if len(diff.fkAdds) > 0 {
+		names := make([]string, len(diff.fkAdds))
+		for i, fk := range diff.fkAdds {
+			names[i] = fk.GetName(tb.name)
+		}
+		tb.dialect.DropForeignKeys(stmt, tb.dbName, tb.name, names)
+		stmts = appendStatement(stmts, stmt)
+	}
+
+	added := make(util.StringSlice, 0)
+	modified := make(util.StringSlice, 0)
+	for _, c := range plan.Columns {
+		switch c.Action {
+		case ChangeAdd:
+			added = append(added, c.Name)
+		case ChangeModify, ChangeReorder:
+			modified = append(modified, c.Name)
+		}
+	}
+
+	// the index on the new column will be dropped together with the column
+	for _, idx := range plan.Indexes {
+		if idx.Action != ChangeAdd || added.IndexOf(idx.Columns[0]) > -1 {
+			continue
+		}
+		if reverter != nil {
+			reverter.DropUniqueIndex(stmt, tb.dbName, tb.name, idx.Name)
+		} else {
+			tb.dialect.DropIndexes(stmt, tb.dbName, tb.name, []string{idx.Name})
+		}
+		stmts = appendStatement(stmts, stmt)
+	}
+
+	for i := len(added) - 1; i >= 0; i-- {
+		tb.dialect.DropColumn(stmt, tb.dbName, tb.name, added[i])
+		stmts = appendStatement(stmts, stmt)
+	}
+
+	// restore the original definition and position of the modified columns
+	if len(modified) > 0 && reverter == nil {
+		return nil, fmt.Errorf("sqlike: dialect %T doesn't support reverting the modified columns", tb.dialect)
+	}
+	for i, col := range diff.columns {
+		if modified.IndexOf(col.Name) < 0 {
+			continue
+		}
+		// the generation expression of generated column is not available
+		if strings.Contains(strings.Replace(col.Extra, "DEFAULT_GENERATED", "", 1), "GENERATED") {
+			continue
+		}
+		after := ""
+		if i > 0 {
+			after = diff.columns[i-1].Name
+		}
+		reverter.ModifyColumn(stmt, tb.dbName, tb.name, col.definition(), after)
+		stmts = appendStatement(stmts, stmt)
+	}
+
+	// restore the original definition of the modified foreign keys
+	if len(diff.fkDrops) > 0 {
+		olds := make([]indexes.ForeignKey, 0, len(diff.fkDrops))
+		for _, fk := range diff.fks {
+			if util.StringSlice(diff.fkDrops).IndexOf(fk.Name) > -1 {
+				olds = append(olds, fk)
+			}
+		}
+		tb.dialect.AddForeignKeys(stmt, tb.dbName, tb.name, olds)
+		stmts = appendStatement(stmts, stmt)
+	}
+	return stmts, nil
+}
+
+// appendStatement : the statement may be empty if the dialect doesn't support the operation
+func appendStatement(stmts []string, stmt *sqlstmt.Statement) []string {
+	if stmt.String() != "" {
+		stmts = append(stmts, fmt.Sprintf("%+v", stmt))
+	}
+	stmt.Reset()
+	return stmts
+}
+
+// plannedColumns : flatten the struct fields into columns, including the generated columns
+func plannedColumns(fields []reflext.StructFielder) []plannedColumn {
+	cols := make([]plannedColumn, 0, len(fields))
+	for _, sf := range fields {
+		cols = append(cols, plannedColumn{sf: sf})
+		if reflext.Deref(sf.Type()).Kind() != reflect.Struct {
+			continue
+		}
+
+		children := sf.Children()
+		for len(children) > 0 {
+			child := children[0]
+			tag := child.Tag()
+			k1, virtual := tag.LookUp("virtual_column")
+			k2, stored := tag.LookUp("stored_column")
+			if virtual || stored {
+				pc := plannedColumn{sf: child}
+				if virtual && k1 != "" {
+					pc.name = k1
+				}
+				if stored && k2 != "" {
+					pc.name = k2
+				}
+				cols = append(cols, pc)
+			}
+			children = children[1:]
+			children = append(children, child.Children()...)
+		}
+	}
+	return cols
+}
+
+func primaryKeyOf(fields []reflext.StructFielder, pk string) string {
+	name := ""
+	for _, sf := range fields {
+		if _, ok := sf.Tag().LookUp("primary_key"); ok {
+			return sf.Name()
+		}
+		if sf.Name() == pk {
+			name = sf.Name()
+		}
+	}
+	return name
+}
+
+// modifyColumn : the change is only destructive when the data type is narrowed or the column becomes not nullable
+func modifyColumn(driver string, old Column, col columns.Column, comment string, hasComment bool) (ColumnChange, bool) {
+	typeChanged := !sameType(driver, old.Type, col.Type)
+	notNull := bool(old.IsNullable) && !col.Nullable
+	attrs := changedAttributes(driver, old, col, comment, hasComment)
+	if !typeChanged && bool(old.IsNullable) == col.Nullable && len(attrs) == 0 {
+		return ColumnChange{}, false
+	}
+	return ColumnChange{
+		Action:      ChangeModify,
+		Name:        col.Name,
+		OldType:     old.Type,
+		NewType:     col.Type,
+		Attributes:  attrs,
+		Destructive: notNull || (typeChanged && isNarrowing(driver, old.Type, col.Type)),
+	}, true
+}
+
+// changedAttributes : compare the column definition which will be written by `AlterTable` with the existing column,
+// the default value is only written when the column is not nullable
+func changedAttributes(driver string, old Column, col columns.Column, comment string, hasComment bool) []string {
+	attrs := make([]string, 0)
+	// the definition of generated column is not available
+	if isGenerated(old.Extra) {
+		return attrs
+	}
+
+	var dflt *string
+	if !col.Nullable {
+		dflt = col.DefaultValue
+	}
+	if !sameDefault(old.DefaultValue, dflt) {
+		attrs = append(attrs, "DEFAULT")
+	}
+	// postgres doesn't support the character set and collation on `ALTER COLUMN`
+	if driver == "mysql" {
+		if col.Charset != nil && !equalFold(old.Charset, *col.Charset) {
+			attrs = append(attrs, "CHARACTER SET")
+		}
+		if col.Collation != nil && !equalFold(old.Collation, *col.Collation) {
+			attrs = append(attrs, "COLLATE")
+		}
+	}
+	// mysql clears the comment on `MODIFY COLUMN` while postgres only sets the comment when it's declared
+	if (hasComment || driver == "mysql") && old.Comment != comment {
+		attrs = append(attrs, "COMMENT")
+	}
+	if normalizeExtra(old.Extra) != normalizeExtra(col.Extra) {
+		attrs = append(attrs, "EXTRA")
+	}
+	return attrs
+}
+
+func isGenerated(extra string) bool {
+	return strings.Contains(strings.ToUpper(strings.Replace(extra, "DEFAULT_GENERATED", "", 1)), "GENERATED")
+}
+
+func equalFold(a *string, b string) bool {
+	return a != nil && strings.EqualFold(*a, b)
+}
+
+// sameDefault : the default value returned by postgres is casted, eg. `'a'::character varying`
+func sameDefault(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	x, y := normalizeDefault(*a), normalizeDefault(*b)
+	if x == y {
+		return true
+	}
+	// the function name is case insensitive, eg. `current_timestamp(6)`
+	return strings.HasSuffix(x, ")") && strings.EqualFold(x, y)
+}
+
+func normalizeDefault(v string) string {
+	v = strings.TrimSpace(v)
+	for {
+		idx := strings.LastIndex(v, "::")
+		if idx < 0 || strings.LastIndex(v, "'") > idx {
+			break
+		}
+		v = v[:idx]
+	}
+	if len(v) > 1 && v[0] == '(' && v[len(v)-1] == ')' {
+		v = v[1 : len(v)-1]
+	}
+	if len(v) > 1 && v[0] == '\'' && v[len(v)-1] == '\'' {
+		v = v[1 : len(v)-1]
+	}
+	return v
+}
+
+// normalizeExtra : mysql reports the default expression as `DEFAULT_GENERATED` and
+// the spatial reference system id isn't part of `EXTRA`
+func normalizeExtra(extra string) string {
+	extra = strings.ToUpper(strings.Replace(extra, "DEFAULT_GENERATED", "", 1))
+	if strings.HasPrefix(extra, "SRID ") {
+		return ""
+	}
+	return strings.Join(strings.Fields(extra), " ")
+}
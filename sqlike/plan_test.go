@@ -0,0 +1,270 @@
+package sqlike
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/dialect/mysql"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/columns"
+	"github.com/stretchr/testify/require"
+)
+
+type planEntity struct {
+	ID     string `sqlike:",primary_key"`
+	Email  string `sqlike:",unique_index"`
+	Detail struct {
+		ICNo    string `sqlike:",virtual_column=ICNo"`
+		PhoneNo string `sqlike:",stored_column"`
+		Age     uint
+	}
+}
+
+func TestMigrationPlan(t *testing.T) {
+	t.Run("Change Action", func(it *testing.T) {
+		require.Equal(it, "ADD", ChangeAdd.String())
+		require.Equal(it, "MODIFY", ChangeModify.String())
+		require.Equal(it, "DROP", ChangeDrop.String())
+		require.Equal(it, "REORDER", ChangeReorder.String())
+	})
+
+	t.Run("HasChanges and IsDestructive", func(it *testing.T) {
+		plan := new(MigrationPlan)
+		require.False(it, plan.HasChanges())
+		require.False(it, plan.IsDestructive())
+
+		plan.Indexes = append(plan.Indexes, IndexChange{Action: ChangeAdd, Name: "UX_Email"})
+		require.True(it, plan.HasChanges())
+		require.False(it, plan.IsDestructive())
+
+		plan.Columns = append(plan.Columns, ColumnChange{Action: ChangeDrop, Name: "Remark", Destructive: true})
+		require.True(it, plan.IsDestructive())
+
+		require.True(it, (&MigrationPlan{Create: true}).HasChanges())
+	})
+
+	t.Run("plannedColumns", func(it *testing.T) {
+		fields := skipColumns(reflext.DefaultMapper.CodecByType(reflect.TypeOf(planEntity{})).Properties(), nil)
+		cols := plannedColumns(fields)
+		names := make([]string, len(cols))
+		for i, pc := range cols {
+			names[i] = pc.columnName(pc.sf.Name())
+		}
+		require.Equal(it, []string{"ID", "Email", "Detail", "ICNo", "Detail.PhoneNo"}, names)
+		require.Equal(it, "ID", primaryKeyOf(fields, "$Key"))
+		require.Equal(it, "Email", primaryKeyOf(fields[1:], "Email"))
+		require.Equal(it, "", primaryKeyOf(fields[1:], "$Key"))
+	})
+
+	t.Run("sameType", func(it *testing.T) {
+		require.True(it, sameType("mysql", "varchar(191)", "VARCHAR(191)"))
+		require.True(it, sameType("mysql", "bigint  unsigned", "BIGINT UNSIGNED"))
+		require.False(it, sameType("mysql", "varchar(191)", "VARCHAR(255)"))
+
+		// the data type returned by `COLUMN_TYPE` of mysql
+		require.True(it, sameType("mysql", "double", "REAL"))
+		require.True(it, sameType("mysql", "int(11)", "INT"))
+		require.True(it, sameType("mysql", "bigint(20) unsigned", "BIGINT UNSIGNED"))
+		require.True(it, sameType("mysql", "tinyint(1)", "TINYINT(1)"))
+		require.True(it, sameType("mysql", "tinyint(4)", "TINYINT"))
+		require.True(it, sameType("mysql", "datetime(6)", "DATETIME(6)"))
+		require.True(it, sameType("mysql", "enum('a','b')", "ENUM('a','b')"))
+		require.False(it, sameType("mysql", "enum('a','b')", "ENUM('a','b','c')"))
+		require.False(it, sameType("mysql", "int(11)", "INT UNSIGNED"))
+		require.False(it, sameType("mysql", "int(11)", "BIGINT"))
+
+		// the data type returned by `information_schema.columns` of postgres
+		require.True(it, sameType("postgres", "character varying(191)", "VARCHAR(191)"))
+		require.True(it, sameType("postgres", "character(3)", "CHAR(3)"))
+		require.True(it, sameType("postgres", "integer", "INTEGER"))
+		require.True(it, sameType("postgres", "bigint", `BIGINT CHECK ("Uint" >= 0)`))
+		require.True(it, sameType("postgres", "double precision", "DOUBLE PRECISION"))
+		require.True(it, sameType("postgres", "real", "REAL"))
+		require.True(it, sameType("postgres", "boolean", "BOOLEAN"))
+		require.True(it, sameType("postgres", "numeric", "NUMERIC(20)"))
+		require.True(it, sameType("postgres", "timestamp without time zone", "TIMESTAMP(6)"))
+		require.True(it, sameType("postgres", "time without time zone", "TIME(6)"))
+		require.True(it, sameType("postgres", "USER-DEFINED", "GEOMETRY(POINT)"))
+		require.False(it, sameType("postgres", "character varying(191)", "VARCHAR(255)"))
+		require.False(it, sameType("postgres", "real", "DOUBLE PRECISION"))
+	})
+
+	t.Run("isNarrowing", func(it *testing.T) {
+		require.False(it, isNarrowing("mysql", "int(11)", "BIGINT"))
+		require.False(it, isNarrowing("mysql", "int(10) unsigned", "BIGINT"))
+		require.False(it, isNarrowing("mysql", "varchar(191)", "VARCHAR(255)"))
+		require.False(it, isNarrowing("mysql", "char(3)", "VARCHAR(191)"))
+		require.False(it, isNarrowing("mysql", "varchar(191)", "TEXT"))
+		require.False(it, isNarrowing("mysql", "float", "REAL"))
+		require.False(it, isNarrowing("mysql", "decimal(10,2)", "DECIMAL(12,4)"))
+		require.False(it, isNarrowing("mysql", "datetime(3)", "DATETIME(6)"))
+		require.False(it, isNarrowing("postgres", "character varying(36)", "VARCHAR(191)"))
+		require.False(it, isNarrowing("postgres", "integer", "BIGINT"))
+
+		require.True(it, isNarrowing("mysql", "bigint(20)", "INT"))
+		require.True(it, isNarrowing("mysql", "int(11)", "INT UNSIGNED"))
+		require.True(it, isNarrowing("mysql", "int(10) unsigned", "INT"))
+		require.True(it, isNarrowing("mysql", "varchar(255)", "VARCHAR(191)"))
+		require.True(it, isNarrowing("mysql", "mediumtext", "TEXT"))
+		require.True(it, isNarrowing("mysql", "text", "VARCHAR(191)"))
+		require.True(it, isNarrowing("mysql", "double", "FLOAT"))
+		require.True(it, isNarrowing("mysql", "decimal(12,4)", "DECIMAL(10,2)"))
+		require.True(it, isNarrowing("mysql", "datetime(6)", "DATETIME(3)"))
+		require.True(it, isNarrowing("mysql", "varchar(191)", "INT"))
+		require.True(it, isNarrowing("postgres", "double precision", "REAL"))
+	})
+
+	t.Run("modifyColumn", func(it *testing.T) {
+		_, ok := modifyColumn("mysql", Column{Type: "int(11)"}, columns.Column{Name: "Age", Type: "INT"}, "", false)
+		require.False(it, ok)
+
+		change, ok := modifyColumn("mysql", Column{Type: "int(11)"}, columns.Column{Name: "Age", Type: "BIGINT"}, "", false)
+		require.True(it, ok)
+		require.Equal(it, ColumnChange{Action: ChangeModify, Name: "Age", OldType: "int(11)", NewType: "BIGINT", Attributes: []string{}}, change)
+
+		change, ok = modifyColumn("mysql", Column{Type: "bigint(20)"}, columns.Column{Name: "Age", Type: "INT"}, "", false)
+		require.True(it, ok)
+		require.True(it, change.Destructive)
+
+		// nullable column becomes not nullable
+		change, ok = modifyColumn("postgres", Column{Type: "character varying(191)", IsNullable: true}, columns.Column{Name: "Name", Type: "VARCHAR(191)"}, "", false)
+		require.True(it, ok)
+		require.True(it, change.Destructive)
+
+		// not nullable column becomes nullable
+		change, ok = modifyColumn("postgres", Column{Type: "character varying(191)"}, columns.Column{Name: "Name", Type: "VARCHAR(191)", Nullable: true}, "", false)
+		require.True(it, ok)
+		require.False(it, change.Destructive)
+	})
+
+	t.Run("changedAttributes", func(it *testing.T) {
+		str := func(v string) *string { return &v }
+
+		// the default value is only written when the column is not nullable
+		attrs := changedAttributes("mysql", Column{DefaultValue: str("")}, columns.Column{DefaultValue: str("")}, "", false)
+		require.Empty(it, attrs)
+		attrs = changedAttributes("mysql", Column{IsNullable: true}, columns.Column{Nullable: true, DefaultValue: str("")}, "", false)
+		require.Empty(it, attrs)
+		attrs = changedAttributes("mysql", Column{DefaultValue: str("0")}, columns.Column{DefaultValue: str("1")}, "", false)
+		require.Equal(it, []string{"DEFAULT"}, attrs)
+		attrs = changedAttributes("postgres", Column{DefaultValue: str("'abc'::character varying")}, columns.Column{DefaultValue: str("abc")}, "", false)
+		require.Empty(it, attrs)
+		attrs = changedAttributes("mysql", Column{DefaultValue: str("CURRENT_TIMESTAMP(6)")}, columns.Column{DefaultValue: str("current_timestamp(6)")}, "", false)
+		require.Empty(it, attrs)
+
+		attrs = changedAttributes("mysql",
+			Column{DefaultValue: str(""), Charset: str("utf8mb4"), Collation: str("utf8mb4_general_ci")},
+			columns.Column{DefaultValue: str(""), Charset: str("utf8mb4"), Collation: str("utf8mb4_unicode_ci")},
+			"", false,
+		)
+		require.Equal(it, []string{"COLLATE"}, attrs)
+
+		// mysql clears the comment when it's not declared
+		attrs = changedAttributes("mysql", Column{Comment: "name"}, columns.Column{}, "", false)
+		require.Equal(it, []string{"COMMENT"}, attrs)
+		attrs = changedAttributes("postgres", Column{Comment: "name"}, columns.Column{}, "", false)
+		require.Empty(it, attrs)
+		attrs = changedAttributes("postgres", Column{Comment: "name"}, columns.Column{}, "full name", true)
+		require.Equal(it, []string{"COMMENT"}, attrs)
+
+		attrs = changedAttributes("mysql",
+			Column{DefaultValue: str("CURRENT_TIMESTAMP(6)"), Extra: "DEFAULT_GENERATED"},
+			columns.Column{DefaultValue: str("CURRENT_TIMESTAMP(6)"), Extra: "ON UPDATE CURRENT_TIMESTAMP(6)"},
+			"", false,
+		)
+		require.Equal(it, []string{"EXTRA"}, attrs)
+		attrs = changedAttributes("mysql",
+			Column{DefaultValue: str("CURRENT_TIMESTAMP(6)"), Extra: "DEFAULT_GENERATED on update CURRENT_TIMESTAMP(6)"},
+			columns.Column{DefaultValue: str("CURRENT_TIMESTAMP(6)"), Extra: "ON UPDATE CURRENT_TIMESTAMP(6)"},
+			"", false,
+		)
+		require.Empty(it, attrs)
+		attrs = changedAttributes("mysql", Column{Extra: "auto_increment"}, columns.Column{Extra: "AUTO_INCREMENT"}, "", false)
+		require.Empty(it, attrs)
+
+		// generated column is skipped
+		attrs = changedAttributes("mysql", Column{Extra: "VIRTUAL GENERATED"}, columns.Column{DefaultValue: str("")}, "", false)
+		require.Empty(it, attrs)
+	})
+
+	t.Run("upStatements", func(it *testing.T) {
+		tb := &Table{dbName: "db", name: "User", dialect: mysql.New()}
+
+		// nothing is executed when there is no changes
+		up, err := tb.upStatements(&migrationDiff{plan: &MigrationPlan{Table: "User"}})
+		require.NoError(it, err)
+		require.Empty(it, up)
+
+		executed := make([]string, 0)
+		require.NoError(it, tb.buildUpStatements(&migrationDiff{plan: &MigrationPlan{Table: "User"}}, func(stmt *sqlstmt.Statement) error {
+			executed = append(executed, stmt.String())
+			return nil
+		}))
+		require.Empty(it, executed)
+
+		// the executed statements are the statements of the plan
+		tb.client = &Client{DriverInfo: &DriverInfo{driverName: "mysql"}}
+		fields := skipColumns(reflext.DefaultMapper.CodecByType(reflect.TypeOf(planEntity{})).Properties(), nil)
+		diff := &migrationDiff{
+			plan: &MigrationPlan{
+				Table:   "User",
+				Columns: []ColumnChange{{Action: ChangeAdd, Name: "Email", NewType: "VARCHAR(191)"}},
+			},
+			fields:  fields,
+			columns: []Column{{Name: "ID"}},
+			hasPk:   true,
+		}
+		up, err = tb.upStatements(diff)
+		require.NoError(it, err)
+		require.Len(it, up, 1)
+		require.NoError(it, tb.buildUpStatements(diff, func(stmt *sqlstmt.Statement) error {
+			executed = append(executed, fmt.Sprintf("%+v", stmt))
+			return nil
+		}))
+		require.Equal(it, up, executed)
+	})
+
+	t.Run("downStatements", func(it *testing.T) {
+		tb := &Table{dbName: "db", name: "User", dialect: mysql.New()}
+		dflt := ""
+		diff := &migrationDiff{
+			plan: &MigrationPlan{
+				Table: "User",
+				Columns: []ColumnChange{
+					{Action: ChangeAdd, Name: "Email", NewType: "VARCHAR(191)"},
+					{Action: ChangeModify, Name: "Age", OldType: "INT(11)", NewType: "BIGINT"},
+					{Action: ChangeModify, Name: "Name", OldType: "VARCHAR(100)", NewType: "VARCHAR(191)"},
+				},
+				Indexes: []IndexChange{
+					{Action: ChangeAdd, Name: "UX_Email", Columns: []string{"Email"}},
+					{Action: ChangeAdd, Name: "UX_Name", Columns: []string{"Name"}},
+				},
+			},
+			columns: []Column{
+				{Name: "ID", Type: "BIGINT(20)"},
+				{Name: "Name", Type: "VARCHAR(100)", DefaultValue: &dflt},
+				{Name: "Age", Type: "INT(11)", IsNullable: true},
+			},
+		}
+		down, err := tb.downStatements(diff)
+		require.NoError(it, err)
+		require.Equal(it, []string{
+			"ALTER TABLE `db`.`User` DROP INDEX `UX_Name`;",
+			"ALTER TABLE `db`.`User` DROP COLUMN `Email`;",
+			"ALTER TABLE `db`.`User` MODIFY COLUMN `Name` VARCHAR(100) NOT NULL DEFAULT '' AFTER `ID`;",
+			"ALTER TABLE `db`.`User` MODIFY COLUMN `Age` INT(11) NULL AFTER `Name`;",
+		}, down)
+
+		// nothing to revert
+		down, err = tb.downStatements(&migrationDiff{plan: &MigrationPlan{Table: "User"}})
+		require.NoError(it, err)
+		require.Empty(it, down)
+
+		// drop the created table
+		down, err = tb.downStatements(&migrationDiff{plan: &MigrationPlan{Table: "User", Create: true}})
+		require.NoError(it, err)
+		require.Equal(it, []string{"DROP TABLE IF EXISTS `db`.`User`;"}, down)
+	})
+}
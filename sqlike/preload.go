@@ -0,0 +1,279 @@
+package sqlike
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/expr"
+	"github.com/RevenueMonster/sqlike/sqlike/actions"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+)
+
+// relationship types :
+const (
+	hasOne    = "has_one"
+	hasMany   = "has_many"
+	belongsTo = "belongs_to"
+)
+
+// relation : the relationship declared by struct tag, eg.
+//
+//	Orders  []Order `sqlike:",has_many=UserID"`
+//	Profile *Profile `sqlike:",has_one=UserID"`
+//	User    *User `sqlike:",belongs_to=UserID"`
+//
+// The foreign key of `has_one` and `has_many` is the column of related table which references
+// the primary key of current table, and the foreign key of `belongs_to` is the column of current table
+// which references the primary key of related table. Use `references` to reference other column
+// instead of primary key and `table` to override the related table name, the default table name is the struct name.
+type relation struct {
+	kind       string
+	field      reflext.StructFielder
+	elem       reflect.Type
+	table      string
+	foreignKey string
+	references string
+}
+
+// relationOf : it will return nil if the field doesn't declare any relationship
+func relationOf(sf reflext.StructFielder) (*relation, error) {
+	tag := sf.Tag()
+	for _, kind := range []string{hasOne, hasMany, belongsTo} {
+		fk, ok := tag.LookUp(kind)
+		if !ok {
+			continue
+		}
+		if fk == "" {
+			return nil, fmt.Errorf("sqlike: missing foreign key of relationship %q on field %q", kind, sf.Name())
+		}
+
+		rel := &relation{kind: kind, field: sf, foreignKey: fk}
+		t := sf.Type()
+		if kind == hasMany {
+			if t.Kind() != reflect.Slice {
+				return nil, fmt.Errorf("sqlike: relationship %q on field %q must be a slice", kind, sf.Name())
+			}
+			t = t.Elem()
+		}
+		if !reflext.IsKind(reflext.Deref(t), reflect.Struct) {
+			return nil, fmt.Errorf("sqlike: relationship %q on field %q must be a struct", kind, sf.Name())
+		}
+		rel.elem = t
+		rel.table = reflext.Deref(t).Name()
+		if v, ok := tag.LookUp("table"); ok && v != "" {
+			rel.table = v
+		}
+		rel.references, _ = tag.LookUp("references")
+		return rel, nil
+	}
+	return nil, nil
+}
+
+// isRelation : relationship field is not a column of the table
+func isRelation(sf reflext.StructFielder) bool {
+	tag := sf.Tag()
+	for _, kind := range []string{hasOne, hasMany, belongsTo} {
+		if _, ok := tag.LookUp(kind); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// skipRelations : omit all the relationship fields
+func skipRelations(sfs []reflext.StructFielder) []reflext.StructFielder {
+	fields := make([]reflext.StructFielder, 0, len(sfs))
+	for _, sf := range sfs {
+		if !isRelation(sf) {
+			fields = append(fields, sf)
+		}
+	}
+	return fields
+}
+
+// preload : eager load the relationship fields of the entities using a batched `IN` query per relationship,
+// `v` must be a slice of struct or pointer of struct.
+func (tb *Table) preload(ctx context.Context, v reflect.Value, fields []string, opt *options.FindOptions) error {
+	if len(fields) == 0 || v.Len() == 0 {
+		return nil
+	}
+
+	// group the nested fields by the first level field, eg. `Orders.Items` will be loaded after `Orders`
+	names := make([]string, 0, len(fields))
+	nested := make(map[string][]string)
+	for _, f := range fields {
+		paths := strings.SplitN(f, ".", 2)
+		if _, ok := nested[paths[0]]; !ok {
+			names = append(names, paths[0])
+			nested[paths[0]] = make([]string, 0)
+		}
+		if len(paths) > 1 {
+			nested[paths[0]] = append(nested[paths[0]], paths[1])
+		}
+	}
+
+	t := reflext.Deref(v.Type().Elem())
+	cdc := tb.client.cache.CodecByType(t)
+	for _, name := range names {
+		sf, ok := cdc.LookUpFieldByName(name)
+		if !ok {
+			return fmt.Errorf("sqlike: preload field %q not found in %v", name, t)
+		}
+		rel, err := relationOf(sf)
+		if err != nil {
+			return err
+		}
+		if rel == nil {
+			return fmt.Errorf("sqlike: preload field %q is not a relationship", name)
+		}
+		if err := tb.preloadRelation(ctx, v, cdc, rel, nested[name], opt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// preloadChunkSize : the maximum number of keys of each `IN` query, so it stays within the placeholder limit of the database
+const preloadChunkSize = 500
+
+func (tb *Table) preloadRelation(ctx context.Context, v reflect.Value, cdc reflext.Structer, rel *relation, nested []string, opt *options.FindOptions) error {
+	relatedCdc := tb.client.cache.CodecByType(reflext.Deref(rel.elem))
+	relatedFields := skipColumns(relatedCdc.Properties(), nil)
+	related := tb.relatedTable(rel.table, relatedFields)
+
+	// `localKey` is the column of current table and `remoteKey` is the column of related table
+	localKey, remoteKey := rel.references, rel.foreignKey
+	if rel.kind == belongsTo {
+		localKey, remoteKey = rel.foreignKey, rel.references
+		if remoteKey == "" {
+			remoteKey = related.pk
+		}
+	} else if localKey == "" {
+		localKey = primaryKeyOf(skipColumns(cdc.Properties(), nil), tb.pk)
+	}
+
+	lf, ok := cdc.LookUpFieldByName(localKey)
+	if !ok {
+		return fmt.Errorf("sqlike: invalid relationship on field %q, column %q not found", rel.field.Name(), localKey)
+	}
+	rf, ok := relatedCdc.LookUpFieldByName(remoteKey)
+	if !ok {
+		return fmt.Errorf("sqlike: invalid relationship on field %q, column %q not found in %q", rel.field.Name(), remoteKey, rel.table)
+	}
+
+	keys := relationKeys(tb.client.cache, v, lf)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	// the soft delete column is derived from the related entity, as the related table might not be registered
+	sd, err := softDeleteOf(relatedFields)
+	if err != nil {
+		return err
+	}
+
+	records := reflect.MakeSlice(reflect.SliceOf(rel.elem), 0, len(keys))
+	for len(keys) > 0 {
+		n := preloadChunkSize
+		if len(keys) < n {
+			n = len(keys)
+		}
+		filters := []interface{}{expr.In(remoteKey, keys[:n])}
+		if sd != nil && !opt.WithDeleted {
+			filters = append(filters, sd.notDeleted())
+		}
+		result, err := related.Find(
+			ctx,
+			actions.Find().Where(filters...),
+			options.Find().
+				SetNoLimit(true).
+				SetDebug(opt.Debug).
+				SetNoResolution(opt.NoResolution).
+				SetWithDeleted(true),
+		)
+		if err != nil {
+			return err
+		}
+		chunk := reflect.New(reflect.SliceOf(rel.elem))
+		if err := result.All(chunk.Interface()); err != nil {
+			return err
+		}
+		records = reflect.AppendSlice(records, chunk.Elem())
+		keys = keys[n:]
+	}
+	if err := related.preload(ctx, records, nested, opt); err != nil {
+		return err
+	}
+
+	assignRelation(tb.client.cache, v, records, rel, lf, rf)
+	return nil
+}
+
+// relationKeys : collect the distinct and non-zero keys of the entities
+func relationKeys(cache reflext.StructMapper, v reflect.Value, lf reflext.StructFielder) []interface{} {
+	keys := make([]interface{}, 0, v.Len())
+	exists := make(map[string]bool)
+	for i := 0; i < v.Len(); i++ {
+		fv := reflext.Indirect(cache.FieldByIndexesReadOnly(v.Index(i), lf.Index()))
+		if !fv.IsValid() || reflext.IsZero(fv) {
+			continue
+		}
+		k := fmt.Sprint(fv.Interface())
+		if exists[k] {
+			continue
+		}
+		exists[k] = true
+		keys = append(keys, fv.Interface())
+	}
+	return keys
+}
+
+// assignRelation : assign the related records to the relationship field of the entities by matching
+// the local key with remote key, the key is compared textually as the data type might be different, eg. `int64` and `uint64`
+func assignRelation(cache reflext.StructMapper, v, records reflect.Value, rel *relation, lf, rf reflext.StructFielder) {
+	groups := make(map[string][]reflect.Value)
+	for i := 0; i < records.Len(); i++ {
+		fv := reflext.Indirect(cache.FieldByIndexesReadOnly(records.Index(i), rf.Index()))
+		if !fv.IsValid() {
+			continue
+		}
+		k := fmt.Sprint(fv.Interface())
+		groups[k] = append(groups[k], records.Index(i))
+	}
+
+	idx := rel.field.Index()
+	for i := 0; i < v.Len(); i++ {
+		ev := v.Index(i)
+		if ev.Kind() == reflect.Ptr && ev.IsNil() {
+			continue
+		}
+		fv := reflext.Indirect(cache.FieldByIndexesReadOnly(ev, lf.Index()))
+		if !fv.IsValid() || reflext.IsZero(fv) {
+			continue
+		}
+		matches := groups[fmt.Sprint(fv.Interface())]
+		// initialize the parent only, so the field remains nil if there is no related record
+		field := reflext.Indirect(cache.FieldByIndexes(ev, idx[:len(idx)-1])).Field(idx[len(idx)-1])
+		if rel.kind == hasMany {
+			slice := reflect.MakeSlice(field.Type(), 0, len(matches))
+			field.Set(reflect.Append(slice, matches...))
+			continue
+		}
+		if len(matches) > 0 {
+			field.Set(matches[0])
+		}
+	}
+}
+
+// relatedTable : the related table is in the same database and connection, and the primary key follows the related entity
+func (tb *Table) relatedTable(name string, fields []reflext.StructFielder) *Table {
+	x := *tb
+	x.name = name
+	if pk := primaryKeyOf(fields, tb.pk); pk != "" {
+		x.pk = pk
+	}
+	return &x
+}
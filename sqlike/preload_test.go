@@ -0,0 +1,203 @@
+package sqlike
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sqlike/actions"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+type preloadUser struct {
+	ID      int64 `sqlike:",primary_key"`
+	Name    string
+	Orders  []preloadOrder  `sqlike:",has_many=UserID"`
+	Profile *preloadProfile `sqlike:",has_one=UserID,table=Profile"`
+}
+
+type preloadProfile struct {
+	ID     int64 `sqlike:",primary_key"`
+	UserID int64
+	User   *preloadUser `sqlike:",belongs_to=UserID"`
+}
+
+type preloadOrder struct {
+	ID     int64 `sqlike:",primary_key"`
+	UserID uint64
+}
+
+func TestPreload(t *testing.T) {
+	cache := reflext.DefaultMapper
+	cdc := cache.CodecByType(reflect.TypeOf(preloadUser{}))
+	lookUp := func(cdc reflext.Structer, name string) reflext.StructFielder {
+		sf, ok := cdc.LookUpFieldByName(name)
+		require.True(t, ok)
+		return sf
+	}
+
+	t.Run("relationOf", func(it *testing.T) {
+		rel, err := relationOf(lookUp(cdc, "Orders"))
+		require.NoError(it, err)
+		require.Equal(it, hasMany, rel.kind)
+		require.Equal(it, "preloadOrder", rel.table)
+		require.Equal(it, "UserID", rel.foreignKey)
+		require.Equal(it, reflect.TypeOf(preloadOrder{}), rel.elem)
+
+		rel, err = relationOf(lookUp(cdc, "Profile"))
+		require.NoError(it, err)
+		require.Equal(it, hasOne, rel.kind)
+		require.Equal(it, "Profile", rel.table)
+		require.Equal(it, reflect.TypeOf(&preloadProfile{}), rel.elem)
+
+		rel, err = relationOf(lookUp(cdc, "Name"))
+		require.NoError(it, err)
+		require.Nil(it, rel)
+
+		invalid := cache.CodecByType(reflect.TypeOf(struct {
+			Orders  preloadOrder   `sqlike:",has_many=UserID"`
+			Profile string         `sqlike:",has_one=UserID"`
+			User    *preloadUser   `sqlike:",belongs_to"`
+			Items   []preloadOrder `sqlike:",has_many="`
+		}{}))
+		for _, sf := range invalid.Properties() {
+			_, err := relationOf(sf)
+			require.Error(it, err)
+		}
+	})
+
+	t.Run("skipColumns", func(it *testing.T) {
+		fields := skipColumns(cdc.Properties(), nil)
+		require.Len(it, fields, 2)
+		require.Equal(it, "ID", fields[0].Name())
+		require.Equal(it, "Name", fields[1].Name())
+		require.Len(it, skipRelations(cdc.Properties()), 2)
+	})
+
+	t.Run("has_many", func(it *testing.T) {
+		users := []preloadUser{{ID: 1}, {ID: 2}, {ID: 1}, {ID: 0}}
+		v := reflect.ValueOf(users)
+		lf, rf := lookUp(cdc, "ID"), lookUp(cache.CodecByType(reflect.TypeOf(preloadOrder{})), "UserID")
+		require.Equal(it, []interface{}{int64(1), int64(2)}, relationKeys(cache, v, lf))
+
+		rel, err := relationOf(lookUp(cdc, "Orders"))
+		require.NoError(it, err)
+		orders := []preloadOrder{{ID: 10, UserID: 1}, {ID: 11, UserID: 1}, {ID: 12, UserID: 3}}
+		assignRelation(cache, v, reflect.ValueOf(orders), rel, lf, rf)
+		require.Equal(it, []preloadOrder{orders[0], orders[1]}, users[0].Orders)
+		require.Equal(it, []preloadOrder{}, users[1].Orders)
+		require.Equal(it, []preloadOrder{orders[0], orders[1]}, users[2].Orders)
+		require.Nil(it, users[3].Orders)
+	})
+
+	t.Run("has_one and belongs_to", func(it *testing.T) {
+		users := []*preloadUser{{ID: 1}, nil, {ID: 2}}
+		profiles := []*preloadProfile{{ID: 5, UserID: 2}, {ID: 6, UserID: 3}}
+		profileCdc := cache.CodecByType(reflect.TypeOf(preloadProfile{}))
+
+		rel, err := relationOf(lookUp(cdc, "Profile"))
+		require.NoError(it, err)
+		assignRelation(cache, reflect.ValueOf(users), reflect.ValueOf(profiles), rel, lookUp(cdc, "ID"), lookUp(profileCdc, "UserID"))
+		require.Nil(it, users[0].Profile)
+		require.Equal(it, profiles[0], users[2].Profile)
+
+		rel, err = relationOf(lookUp(profileCdc, "User"))
+		require.NoError(it, err)
+		assignRelation(cache, reflect.ValueOf(profiles), reflect.ValueOf(users), rel, lookUp(profileCdc, "UserID"), lookUp(cdc, "ID"))
+		require.Equal(it, users[2], profiles[0].User)
+		require.Nil(it, profiles[1].User)
+	})
+}
+
+type preloadPageOrder struct {
+	UserID int64 `sqlike:"value"`
+}
+
+type preloadPageUser struct {
+	ID     int64              `sqlike:"value"`
+	Orders []preloadPageOrder `sqlike:",has_many=value"`
+}
+
+type preloadDeletedOrder struct {
+	ID        int64      `sqlike:"value"`
+	DeletedAt *time.Time `sqlike:",soft_delete"`
+}
+
+type preloadChunkUser struct {
+	ID     int64                 `sqlike:"value"`
+	Orders []preloadDeletedOrder `sqlike:",has_many=value"`
+}
+
+func TestPreloadResult(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Decode using Next", func(it *testing.T) {
+		rslt := &Result{preload: func(v reflect.Value) error { return nil }}
+		var user preloadUser
+		require.Equal(it, ErrPreloadOnCursor, rslt.Decode(&user))
+	})
+
+	t.Run("Paginator", func(it *testing.T) {
+		db, d := newTxDatabase(it)
+		db.pk = "value"
+		tb := db.Table("preloadPageUser")
+		require.NoError(it, tb.Register(preloadPageUser{}))
+		require.NoError(it, db.Table("preloadPageOrder").Register(preloadPageOrder{}))
+		d.rows["FROM `db`.`preloadPageUser`"] = []driver.Value{int64(1), int64(2)}
+		d.rows["FROM `db`.`preloadPageOrder`"] = []driver.Value{int64(2)}
+
+		pg, err := tb.Paginate(ctx, actions.Paginate().Limit(10), options.Paginate().SetPreload("Orders"))
+		require.NoError(it, err)
+		users := []preloadPageUser{}
+		require.NoError(it, pg.All(&users))
+		require.Equal(it, []preloadPageUser{
+			{ID: 1, Orders: []preloadPageOrder{}},
+			{ID: 2, Orders: []preloadPageOrder{{UserID: 2}}},
+		}, users)
+		require.Len(it, d.history(), 2)
+	})
+
+	t.Run("unregistered related table with soft delete and chunked keys", func(it *testing.T) {
+		db, d := newTxDatabase(it)
+		db.pk = "value"
+		users := make([]driver.Value, preloadChunkSize+1)
+		for i := range users {
+			users[i] = int64(i + 1)
+		}
+		d.rows["FROM `db`.`preloadChunkUser`"] = users
+
+		result, err := db.Table("preloadChunkUser").Find(ctx, actions.Find(), options.Find().SetPreload("Orders"))
+		require.NoError(it, err)
+		found := []preloadChunkUser{}
+		require.NoError(it, result.All(&found))
+		require.Len(it, found, preloadChunkSize+1)
+
+		queries := make([]string, 0)
+		for _, q := range d.history() {
+			if strings.Contains(q, "preloadDeletedOrder") {
+				queries = append(queries, q)
+			}
+		}
+		require.Len(it, queries, 2)
+		for _, q := range queries {
+			require.Contains(it, q, "`DeletedAt` IS NULL")
+		}
+	})
+
+	t.Run("relatedTable", func(it *testing.T) {
+		tb := &Table{name: "User", pk: "$Key"}
+		fields := skipColumns(reflext.DefaultMapper.CodecByType(reflect.TypeOf(preloadOrder{})).Properties(), nil)
+		related := tb.relatedTable("Order", fields)
+		require.Equal(it, "Order", related.name)
+		require.Equal(it, "ID", related.pk)
+		require.Equal(it, "$Key", tb.pk)
+
+		related = tb.relatedTable("Order", skipColumns(reflext.DefaultMapper.CodecByType(reflect.TypeOf(preloadPageOrder{})).Properties(), nil))
+		require.Equal(it, "$Key", related.pk)
+	})
+}
@@ -0,0 +1,16 @@
+package primitive
+
+// CTE : common table expression, the recursive part will be joined with the anchor using `UNION ALL`
+type CTE struct {
+	Name      string
+	Columns   []string
+	Recursive bool
+	Anchor    interface{}
+	Recursion interface{}
+}
+
+// WithColumns :
+func (x CTE) WithColumns(columns ...string) CTE {
+	x.Columns = columns
+	return x
+}
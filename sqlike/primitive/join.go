@@ -0,0 +1,37 @@
+package primitive
+
+type join int
+
+// joins :
+const (
+	InnerJoin join = iota + 1
+	LeftJoin
+	RightJoin
+	CrossJoin
+)
+
+func (j join) String() string {
+	switch j {
+	case LeftJoin:
+		return "LEFT JOIN"
+	case RightJoin:
+		return "RIGHT JOIN"
+	case CrossJoin:
+		return "CROSS JOIN"
+	default:
+		return "INNER JOIN"
+	}
+}
+
+// Using :
+type Using struct {
+	Columns []string
+}
+
+// Join :
+type Join struct {
+	Type  join
+	Table interface{}
+	On    Group
+	Using []string
+}
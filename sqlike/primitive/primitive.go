@@ -132,6 +132,11 @@ type Math struct {
 	Value int
 }
 
+// Inserted : the value which is going to be inserted into the column, only applicable on conflict update
+type Inserted struct {
+	Field string
+}
+
 type order int
 
 // orders :
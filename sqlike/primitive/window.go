@@ -0,0 +1,102 @@
+package primitive
+
+type windowFunc int
+
+// window functions :
+const (
+	RowNumber windowFunc = iota + 1
+	Rank
+	DenseRank
+	Lag
+	Lead
+	FirstValue
+)
+
+func (f windowFunc) String() string {
+	switch f {
+	case Rank:
+		return "RANK"
+	case DenseRank:
+		return "DENSE_RANK"
+	case Lag:
+		return "LAG"
+	case Lead:
+		return "LEAD"
+	case FirstValue:
+		return "FIRST_VALUE"
+	default:
+		return "ROW_NUMBER"
+	}
+}
+
+// WindowFunc :
+type WindowFunc struct {
+	Type windowFunc
+	Args []interface{}
+}
+
+type frameUnit int
+
+// frame units :
+const (
+	Rows frameUnit = iota + 1
+	Range
+)
+
+func (u frameUnit) String() string {
+	if u == Range {
+		return "RANGE"
+	}
+	return "ROWS"
+}
+
+// Frame :
+type Frame struct {
+	Unit  frameUnit
+	Start interface{}
+	End   interface{}
+}
+
+// Window : window specification of `OVER` clause, `Base` is the name of window to inherit from
+type Window struct {
+	Base       string
+	Partitions []interface{}
+	Sorts      []interface{}
+	Frame      *Frame
+}
+
+// PartitionBy :
+func (w *Window) PartitionBy(fields ...interface{}) *Window {
+	w.Partitions = append(w.Partitions, fields...)
+	return w
+}
+
+// OrderBy :
+func (w *Window) OrderBy(fields ...interface{}) *Window {
+	w.Sorts = append(w.Sorts, fields...)
+	return w
+}
+
+// Rows : `ROWS BETWEEN start AND end`
+func (w *Window) Rows(start, end interface{}) *Window {
+	w.Frame = &Frame{Unit: Rows, Start: start, End: end}
+	return w
+}
+
+// Range : `RANGE BETWEEN start AND end`
+func (w *Window) Range(start, end interface{}) *Window {
+	w.Frame = &Frame{Unit: Range, Start: start, End: end}
+	return w
+}
+
+// Over : `Window` is either `*Window` or the name of window declared in `WINDOW` clause
+type Over struct {
+	Field  interface{}
+	Window interface{}
+}
+
+// NamedWindow :
+type NamedWindow struct {
+	Name   string
+	Window *Window
+}
@@ -15,6 +15,12 @@ func (tb *Table) ReplaceOne(ctx context.Context, src interface{}, opts ...*optio
 	if len(opts) > 0 && opts[0] != nil {
 		opt = opts[0]
 	}
+	if records, ok := mapRecords(src); ok {
+		if len(records) != 1 {
+			return nil, ErrInvalidInput
+		}
+		return tb.insertMaps(ctx, records, &opt.InsertOptions)
+	}
 	v := reflect.ValueOf(src)
 	if !v.IsValid() {
 		return nil, ErrInvalidInput
@@ -40,6 +46,7 @@ func (tb *Table) ReplaceOne(ctx context.Context, src interface{}, opts ...*optio
 		tb.driver,
 		tb.dialect,
 		tb.logger,
+		tb.now(),
 		arr.Interface(),
 		&opt.InsertOptions,
 	)
@@ -1,6 +1,7 @@
 package sqlike
 
 import (
+	"context"
 	"database/sql"
 	"io"
 	"reflect"
@@ -26,8 +27,12 @@ var ErrNoRows = sql.ErrNoRows
 // EOF : is an alias for end of file
 var EOF = io.EOF
 
+// ErrPreloadOnCursor : preload requires all the records to be loaded, it can't be used when decoding the records one by one using `Next`
+var ErrPreloadOnCursor = errors.New("sqlike: preload is not supported when decoding using Next, use All instead")
+
 // Result :
 type Result struct {
+	ctx         context.Context
 	close       bool
 	rows        *sql.Rows
 	codec       codec.Codecer
@@ -35,6 +40,9 @@ type Result struct {
 	columns     []string
 	columnTypes []*sql.ColumnType
 	err         error
+
+	// eager load the relationship fields after the records are decoded
+	preload func(v reflect.Value) error
 }
 
 var _ Resulter = (*Result)(nil)
@@ -110,6 +118,10 @@ func (r *Result) Decode(dst interface{}) error {
 	if r.err != nil {
 		return r.err
 	}
+	// the preload query can't be executed while the rows is still open, as the connection can't be shared in transaction
+	if !r.close && r.preload != nil {
+		return ErrPreloadOnCursor
+	}
 
 	v := reflext.ValueOf(dst)
 	if !v.IsValid() {
@@ -145,9 +157,21 @@ func (r *Result) Decode(dst interface{}) error {
 			return err
 		}
 	}
+	if err := takeSnapshot(r.cache, r.codec, vv, r.columns); err != nil {
+		return err
+	}
+	if err := afterLoad(r.ctx, vv); err != nil {
+		return err
+	}
 	reflext.IndirectInit(v).Set(reflext.Indirect(vv))
 	if r.close {
-		return r.Close()
+		if err := r.Close(); err != nil {
+			return err
+		}
+		if r.preload != nil {
+			ptrs := reflect.MakeSlice(reflect.SliceOf(reflect.PtrTo(t)), 0, 1)
+			return r.preload(reflect.Append(ptrs, reflext.IndirectInit(v).Addr()))
+		}
 	}
 	return nil
 }
@@ -245,10 +269,22 @@ func (r *Result) All(results interface{}) error {
 				return err
 			}
 		}
+		if err := takeSnapshot(r.cache, r.codec, vv, r.columns); err != nil {
+			return err
+		}
+		if err := afterLoad(r.ctx, vv); err != nil {
+			return err
+		}
 		slice = reflect.Append(slice, vv)
 	}
 	v.Set(slice)
-	return r.rows.Close()
+	if err := r.rows.Close(); err != nil {
+		return err
+	}
+	if r.preload != nil {
+		return r.preload(v)
+	}
+	return nil
 }
 
 // Error :
@@ -0,0 +1,110 @@
+package sqlike
+
+import (
+	"reflect"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/codec"
+)
+
+// Snapshot : embed it into the entity to enable dirty tracking. The original values are recorded when the entity is decoded
+// using `Result.Decode` or `Result.All`, so `ModifyOne` will only update the columns which are changed.
+//
+//	type User struct {
+//		sqlike.Snapshot
+//		ID   int64
+//		Name string
+//	}
+type Snapshot struct {
+	values map[string]interface{}
+}
+
+// Reset : discard the recorded values, so `ModifyOne` will update all the columns
+func (s *Snapshot) Reset() {
+	s.values = nil
+}
+
+func (s *Snapshot) snapshot() *Snapshot {
+	return s
+}
+
+type snapshotter interface {
+	snapshot() *Snapshot
+}
+
+// snapshotOf : it will return nil if the entity doesn't embed `Snapshot`
+func snapshotOf(v reflect.Value) *Snapshot {
+	if s, ok := hookOf(v).(snapshotter); ok {
+		return s.snapshot()
+	}
+	return nil
+}
+
+// record : record the encoded values of the fields, the previous values of other fields are kept.
+// The values are written to a new map, as the map is shared by the copies of the entity, eg. `u2 := u1`.
+func (s *Snapshot) record(cache reflext.StructMapper, cdc codec.Codecer, v reflect.Value, fields []reflext.StructFielder) error {
+	values := make(map[string]interface{}, len(s.values)+len(fields))
+	for k, val := range s.values {
+		values[k] = val
+	}
+	for _, sf := range fields {
+		val, err := encodeField(cache, cdc, v, sf)
+		if err != nil {
+			return err
+		}
+		values[sf.Name()] = val
+	}
+	s.values = values
+	return nil
+}
+
+// changed : the field is consider as changed if there is no recorded value
+func (s *Snapshot) changed(cache reflext.StructMapper, cdc codec.Codecer, v reflect.Value, sf reflext.StructFielder) (bool, error) {
+	if s == nil {
+		return true, nil
+	}
+	prev, ok := s.values[sf.Name()]
+	if !ok {
+		return true, nil
+	}
+	val, err := encodeField(cache, cdc, v, sf)
+	if err != nil {
+		return false, err
+	}
+	return !reflect.DeepEqual(prev, val), nil
+}
+
+// takeSnapshot : record the decoded columns of the entity if it embeds `Snapshot`
+func takeSnapshot(cache reflext.StructMapper, cdc codec.Codecer, v reflect.Value, columns []string) error {
+	s := snapshotOf(v)
+	if s == nil {
+		return nil
+	}
+	def := cache.CodecByType(reflext.Deref(v.Type()))
+	fields := make([]reflext.StructFielder, 0, len(columns))
+	for _, col := range columns {
+		if sf, ok := def.LookUpFieldByName(col); ok {
+			fields = append(fields, sf)
+		}
+	}
+	s.values = nil
+	return s.record(cache, cdc, reflext.Indirect(v), fields)
+}
+
+// encodeField : compare the encoded value instead of the field value, so the changes of slice, map and pointer can be detected
+func encodeField(cache reflext.StructMapper, cdc codec.Codecer, v reflect.Value, sf reflext.StructFielder) (interface{}, error) {
+	fv := cache.FieldByIndexesReadOnly(v, sf.Index())
+	encoder, err := cdc.LookupEncoder(fv)
+	if err != nil {
+		return nil, err
+	}
+	val, err := encoder(sf, fv)
+	if err != nil {
+		return nil, err
+	}
+	// the encoded bytes might share the same underlying array with the field
+	if b, ok := val.([]byte); ok {
+		val = append([]byte(nil), b...)
+	}
+	return val, nil
+}
@@ -0,0 +1,146 @@
+package sqlike
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/codec"
+	"github.com/RevenueMonster/sqlike/sql/dialect/mysql"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+// execDriver : fake driver which records the last executed statement
+type execDriver struct {
+	query string
+	args  []interface{}
+}
+
+func (d *execDriver) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	d.query, d.args = query, args
+	return batchResult(1), nil
+}
+
+func (d *execDriver) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errors.New("not supported")
+}
+
+func (d *execDriver) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+
+type snapshotEntity struct {
+	Snapshot
+	ID        int64 `sqlike:",primary_key"`
+	Name      string
+	Tags      []string
+	Age       int
+	UpdatedAt time.Time `sqlike:",auto_update_time"`
+}
+
+func TestSnapshot(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		cache = reflext.DefaultMapper
+		cdc   = codec.DefaultRegistry
+		now   = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	newTable := func() (*Table, *execDriver) {
+		driver := new(execDriver)
+		client := &Client{cache: cache}
+		client.SetClock(func() time.Time { return now })
+		return &Table{
+			dbName:  "db",
+			name:    "User",
+			pk:      "$Key",
+			client:  client,
+			driver:  driver,
+			dialect: mysql.New(),
+			codec:   cdc,
+		}, driver
+	}
+	decode := func(ent *snapshotEntity) {
+		require.NoError(t, takeSnapshot(cache, cdc, reflect.ValueOf(ent), []string{"ID", "Name", "Tags", "Age", "UpdatedAt"}))
+	}
+
+	t.Run("Snapshot is not a column", func(it *testing.T) {
+		fields := skipColumns(cache.CodecByType(reflect.TypeOf(snapshotEntity{})).Properties(), nil)
+		names := make([]string, len(fields))
+		for i, sf := range fields {
+			names[i] = sf.Name()
+		}
+		require.Equal(it, []string{"ID", "Name", "Tags", "Age", "UpdatedAt"}, names)
+	})
+
+	t.Run("without snapshot", func(it *testing.T) {
+		tb, driver := newTable()
+		ent := snapshotEntity{ID: 1, Name: "John", Age: 18}
+		var changes []string
+		require.NoError(it, tb.ModifyOne(ctx, &ent, options.ModifyOne().SetChangedFields(&changes)))
+		require.Equal(it, []string{"Name", "Tags", "Age"}, changes)
+		require.Contains(it, driver.query, "`Name` = ?")
+		require.Contains(it, driver.query, "`Age` = ?")
+	})
+
+	t.Run("only changed columns", func(it *testing.T) {
+		tb, driver := newTable()
+		ent := snapshotEntity{ID: 1, Name: "John", Tags: []string{"a"}, Age: 18}
+		decode(&ent)
+
+		ent.Name = "Doe"
+		ent.Tags[0] = "b"
+		var changes []string
+		require.NoError(it, tb.ModifyOne(ctx, &ent, options.ModifyOne().SetChangedFields(&changes)))
+		require.Equal(it, []string{"Name", "Tags"}, changes)
+		require.NotContains(it, driver.query, "`Age`")
+		require.Contains(it, driver.query, "`UpdatedAt` = ?")
+		require.Equal(it, now, ent.UpdatedAt)
+
+		// the snapshot is refreshed after modified
+		driver.query = ""
+		require.NoError(it, tb.ModifyOne(ctx, &ent, options.ModifyOne().SetChangedFields(&changes)))
+		require.Empty(it, changes)
+		require.Empty(it, driver.query)
+	})
+
+	t.Run("nothing changed", func(it *testing.T) {
+		tb, driver := newTable()
+		ent := snapshotEntity{ID: 1, Name: "John"}
+		decode(&ent)
+		require.NoError(it, tb.ModifyOne(ctx, &ent))
+		require.Empty(it, driver.query)
+		require.True(it, ent.UpdatedAt.IsZero())
+	})
+
+	t.Run("Reset", func(it *testing.T) {
+		tb, _ := newTable()
+		ent := snapshotEntity{ID: 1, Name: "John"}
+		decode(&ent)
+		ent.Reset()
+		var changes []string
+		require.NoError(it, tb.ModifyOne(ctx, &ent, options.ModifyOne().SetChangedFields(&changes)))
+		require.Equal(it, []string{"Name", "Tags", "Age"}, changes)
+	})
+
+	t.Run("copied entity", func(it *testing.T) {
+		tb, _ := newTable()
+		u1 := snapshotEntity{ID: 1, Name: "John"}
+		decode(&u1)
+
+		u2 := u1
+		u2.Name = "Doe"
+		require.NoError(it, tb.ModifyOne(ctx, &u2))
+
+		// the baseline of the original entity is not changed by the copy
+		u1.Name = "Doe"
+		var changes []string
+		require.NoError(it, tb.ModifyOne(ctx, &u1, options.ModifyOne().SetChangedFields(&changes)))
+		require.Equal(it, []string{"Name"}, changes)
+	})
+}
@@ -0,0 +1,173 @@
+package sqlike
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/expr"
+	"github.com/RevenueMonster/sqlike/sqlike/actions"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+	"github.com/RevenueMonster/sqlike/sqlike/primitive"
+)
+
+// ErrNoSoftDelete :
+var ErrNoSoftDelete = errors.New("sqlike: table doesn't have soft delete column, please register the entity with `soft_delete` tag using `Register` or `Migrate`")
+
+// softDelete : the marker column of soft delete, it's either a boolean or a nullable timestamp column
+type softDelete struct {
+	column  string
+	boolean bool
+}
+
+// marker : the value to mark the record as deleted
+func (sd *softDelete) marker(now time.Time) interface{} {
+	if sd.boolean {
+		return true
+	}
+	return now
+}
+
+// unmarker : the value to restore the record
+func (sd *softDelete) unmarker() interface{} {
+	if sd.boolean {
+		return false
+	}
+	return nil
+}
+
+// notDeleted : the condition of records which are not deleted
+func (sd *softDelete) notDeleted() interface{} {
+	if sd.boolean {
+		return expr.Equal(sd.column, false)
+	}
+	return expr.IsNull(sd.column)
+}
+
+// deleted : the condition of records which are deleted
+func (sd *softDelete) deleted() interface{} {
+	if sd.boolean {
+		return expr.Equal(sd.column, true)
+	}
+	return expr.NotNull(sd.column)
+}
+
+// markDeleted : convert the delete action to update action which mark the records as deleted
+func (sd *softDelete) markDeleted(act *actions.DeleteActions, now time.Time) *actions.UpdateActions {
+	x := new(actions.UpdateActions)
+	x.Database = act.Database
+	x.Table = act.Table
+	x.Conditions = expr.And(primitive.Group{Values: act.Conditions}, sd.notDeleted()).Values
+	x.Values = []primitive.KV{expr.ColumnValue(sd.column, sd.marker(now))}
+	x.Sorts = act.Sorts
+	x.Record = act.Record
+	return x
+}
+
+// softDeleteOf : lookup the field with `soft_delete` tag, it will return nil if there is no soft delete column.
+func softDeleteOf(fields []reflext.StructFielder) (*softDelete, error) {
+	for _, sf := range fields {
+		if _, ok := sf.Tag().LookUp("soft_delete"); !ok {
+			continue
+		}
+
+		t := sf.Type()
+		switch {
+		case reflext.Deref(t).Kind() == reflect.Bool:
+			return &softDelete{column: sf.Name(), boolean: true}, nil
+		case t.Kind() == reflect.Ptr && t.Elem() == reflect.TypeOf(time.Time{}):
+			return &softDelete{column: sf.Name()}, nil
+		default:
+			return nil, fmt.Errorf("sqlike: soft delete column %q must be either bool or *time.Time", sf.Name())
+		}
+	}
+	return nil, nil
+}
+
+// Register : register the entity to the table, so the table is aware of the `soft_delete` column when the entity is not provided, eg. `Find`, `Delete`.
+// `Migrate` and `UnsafeMigrate` will register the entity as well.
+func (tb *Table) Register(entity interface{}) error {
+	v := reflext.ValueOf(entity)
+	if !v.IsValid() {
+		return ErrInvalidInput
+	}
+
+	t := reflext.Deref(v.Type())
+	if !reflext.IsKind(t, reflect.Struct) {
+		return ErrExpectedStruct
+	}
+	return tb.register(skipColumns(tb.client.cache.CodecByType(t).Properties(), nil))
+}
+
+func (tb *Table) register(fields []reflext.StructFielder) error {
+	sd, err := softDeleteOf(fields)
+	if err != nil {
+		return err
+	}
+	key := tb.dbName + "." + tb.name
+	if sd == nil {
+		tb.client.softDeletes.Delete(key)
+		return nil
+	}
+	tb.client.softDeletes.Store(key, sd)
+	return nil
+}
+
+func (tb *Table) softDelete() *softDelete {
+	if tb.client == nil {
+		return nil
+	}
+	v, ok := tb.client.softDeletes.Load(tb.dbName + "." + tb.name)
+	if !ok {
+		return nil
+	}
+	return v.(*softDelete)
+}
+
+// excludeDeleted : exclude the soft deleted records, unless `WithDeleted` is set
+func (tb *Table) excludeDeleted(act *actions.FindActions, opt *options.FindOptions) {
+	sd := tb.softDelete()
+	if sd == nil || opt.WithDeleted {
+		return
+	}
+	// only apply on current table
+	if act.Table != "" && act.Table != tb.name {
+		return
+	}
+	values := act.Conditions.Values[:len(act.Conditions.Values):len(act.Conditions.Values)]
+	if len(values) > 0 {
+		values = append(values, primitive.And)
+	}
+	act.Conditions.Values = append(values, sd.notDeleted())
+}
+
+// Restore : restore the soft deleted records which match the where clause, the values of `Set` will be updated as well.
+func (tb *Table) Restore(ctx context.Context, act actions.UpdateStatement, opts ...*options.UpdateOptions) (int64, error) {
+	sd := tb.softDelete()
+	if sd == nil {
+		return 0, ErrNoSoftDelete
+	}
+	x := new(actions.UpdateActions)
+	if act != nil {
+		*x = *(act.(*actions.UpdateActions))
+	}
+	opt := new(options.UpdateOptions)
+	if len(opts) > 0 && opts[0] != nil {
+		opt = opts[0]
+	}
+	x.Conditions = expr.And(primitive.Group{Values: x.Conditions}, sd.deleted()).Values
+	x.Values = append(x.Values[:len(x.Values):len(x.Values)], expr.ColumnValue(sd.column, sd.unmarker()))
+	return update(
+		ctx,
+		tb.dbName,
+		tb.name,
+		tb.driver,
+		tb.dialect,
+		tb.logger,
+		x,
+		opt,
+	)
+}
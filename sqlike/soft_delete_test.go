@@ -0,0 +1,126 @@
+package sqlike
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/codec"
+	"github.com/RevenueMonster/sqlike/sql/dialect/mysql"
+	"github.com/RevenueMonster/sqlike/sql/expr"
+	sqlstmt "github.com/RevenueMonster/sqlike/sql/stmt"
+	"github.com/RevenueMonster/sqlike/sqlike/actions"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+type softDeleteEntity struct {
+	ID        int64 `sqlike:",primary_key"`
+	Name      string
+	DeletedAt *time.Time `sqlike:",soft_delete"`
+}
+
+type softDeleteFlagEntity struct {
+	ID        int64 `sqlike:",primary_key"`
+	IsDeleted bool  `sqlike:",soft_delete"`
+}
+
+func TestSoftDelete(t *testing.T) {
+	fieldsOf := func(it interface{}) []reflext.StructFielder {
+		return reflext.DefaultMapper.CodecByType(reflect.TypeOf(it)).Properties()
+	}
+
+	t.Run("softDeleteOf", func(it *testing.T) {
+		sd, err := softDeleteOf(fieldsOf(softDeleteEntity{}))
+		require.NoError(it, err)
+		require.Equal(it, &softDelete{column: "DeletedAt"}, sd)
+		require.Nil(it, sd.unmarker())
+		now := time.Now()
+		require.Equal(it, now, sd.marker(now))
+
+		sd, err = softDeleteOf(fieldsOf(softDeleteFlagEntity{}))
+		require.NoError(it, err)
+		require.Equal(it, &softDelete{column: "IsDeleted", boolean: true}, sd)
+		require.Equal(it, true, sd.marker(now))
+		require.Equal(it, false, sd.unmarker())
+
+		sd, err = softDeleteOf(fieldsOf(hookEntity{}))
+		require.NoError(it, err)
+		require.Nil(it, sd)
+
+		_, err = softDeleteOf(fieldsOf(struct {
+			DeletedAt time.Time `sqlike:",soft_delete"`
+		}{}))
+		require.Error(it, err)
+	})
+
+	t.Run("markDeleted", func(it *testing.T) {
+		ms := mysql.New()
+		stmt := sqlstmt.AcquireStmt(ms)
+		defer sqlstmt.ReleaseStmt(stmt)
+
+		act := actions.Delete().Where(
+			expr.Equal("Name", "test"),
+			expr.GreaterThan("ID", 10),
+		).(*actions.DeleteActions)
+		act.Database = "db"
+		act.Table = "table"
+
+		sd := &softDelete{column: "IsDeleted", boolean: true}
+		require.NoError(it, ms.Update(stmt, sd.markDeleted(act, time.Now())))
+		require.Equal(it, "UPDATE `db`.`table` SET `IsDeleted` = ? WHERE ((`Name` = ? AND `ID` > ?) AND `IsDeleted` = ?);", stmt.String())
+		require.Equal(it, []interface{}{true, "test", int64(10), false}, stmt.Args())
+	})
+
+	t.Run("excludeDeleted", func(it *testing.T) {
+		tb := &Table{dbName: "db", name: "User", client: &Client{cache: reflext.DefaultMapper}}
+
+		act := actions.Find().Where(expr.Equal("Name", "test")).(*actions.FindActions)
+		tb.excludeDeleted(act, options.Find())
+		require.Len(it, act.Conditions.Values, 1)
+
+		require.NoError(it, tb.Register(softDeleteEntity{}))
+		tb.excludeDeleted(act, options.Find().SetWithDeleted(true))
+		require.Len(it, act.Conditions.Values, 1)
+
+		tb.excludeDeleted(act, options.Find())
+		require.Len(it, act.Conditions.Values, 3)
+		require.Equal(it, expr.IsNull("DeletedAt"), act.Conditions.Values[2])
+
+		// other table shouldn't be affected
+		other := actions.Find().From("db", "Other").(*actions.FindActions)
+		tb.excludeDeleted(other, options.Find())
+		require.Len(it, other.Conditions.Values, 0)
+
+		// share the registry across table instances
+		require.NotNil(it, (&Table{dbName: "db", name: "User", client: tb.client}).softDelete())
+		require.NoError(it, tb.Register(hookEntity{}))
+		require.Nil(it, tb.softDelete())
+	})
+
+	t.Run("DestroyOne uses the registered column", func(it *testing.T) {
+		driver := new(execDriver)
+		tb := &Table{
+			dbName:  "db",
+			name:    "User",
+			pk:      "$Key",
+			client:  &Client{cache: reflext.DefaultMapper},
+			driver:  driver,
+			dialect: mysql.New(),
+			codec:   codec.DefaultRegistry,
+		}
+		require.NoError(it, tb.Register(softDeleteEntity{}))
+
+		// the entity without `soft_delete` tag is soft deleted as well
+		type partial struct {
+			ID int64 `sqlike:",primary_key"`
+		}
+		require.NoError(it, tb.DestroyOne(context.Background(), &partial{ID: 1}))
+		require.Equal(it, "UPDATE `db`.`User` SET `DeletedAt` = ? WHERE (`ID` = ? AND `DeletedAt` IS NULL) LIMIT 1;", driver.query)
+
+		require.NoError(it, tb.DestroyOne(context.Background(), &partial{ID: 1}, options.DestroyOne().SetHardDelete(true)))
+		require.Equal(it, "DELETE FROM `db`.`User` WHERE `ID` = ? LIMIT 1;", driver.query)
+	})
+}
@@ -0,0 +1,141 @@
+//go:build cgo
+// +build cgo
+
+package sqlike
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/RevenueMonster/sqlike/sql/expr"
+	"github.com/RevenueMonster/sqlike/sqlike/actions"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+type sqliteUser struct {
+	ID     int64 `sqlike:",primary_key"`
+	Name   string
+	Age    int
+	Remark string
+}
+
+type sqliteUserV2 struct {
+	ID    int64 `sqlike:",primary_key"`
+	Name  string
+	Age   int
+	Email string
+}
+
+func TestSQLite(t *testing.T) {
+	ctx := context.Background()
+	client, err := Connect(ctx, "sqlite3", options.Connect().ApplyURI("file:"+filepath.Join(t.TempDir(), "sqlike.db")))
+	require.NoError(t, err)
+	defer client.Close()
+
+	db := client.Database("sqlike")
+	tb := db.Table("User")
+
+	t.Run("Migrate", func(it *testing.T) {
+		require.NoError(it, tb.Migrate(ctx, sqliteUser{}))
+		require.True(it, tb.Exists(ctx))
+
+		// the table has no changes, so nothing will be executed
+		plan, err := tb.PlanMigrate(ctx, sqliteUser{})
+		require.NoError(it, err)
+		require.False(it, plan.HasChanges())
+		require.NoError(it, tb.Migrate(ctx, sqliteUser{}))
+	})
+
+	t.Run("InsertOne and Find", func(it *testing.T) {
+		users := []sqliteUser{
+			{ID: 1, Name: "John", Age: 20, Remark: "first"},
+			{ID: 2, Name: "Mary", Age: 30},
+		}
+		for _, u := range users {
+			_, err := tb.InsertOne(ctx, &u)
+			require.NoError(it, err)
+		}
+
+		var u sqliteUser
+		require.NoError(it, tb.FindOne(ctx, actions.FindOne().Where(expr.Equal("ID", 1))).Decode(&u))
+		require.Equal(it, users[0], u)
+
+		result, err := tb.Find(ctx, actions.Find().OrderBy(expr.Asc("ID")))
+		require.NoError(it, err)
+		found := make([]sqliteUser, 0)
+		require.NoError(it, result.All(&found))
+		require.Equal(it, users, found)
+	})
+
+	t.Run("ModifyOne", func(it *testing.T) {
+		u := sqliteUser{ID: 2, Name: "Mary Jane", Age: 31}
+		require.NoError(it, tb.ModifyOne(ctx, &u))
+
+		var found sqliteUser
+		require.NoError(it, tb.FindOne(ctx, actions.FindOne().Where(expr.Equal("ID", 2))).Decode(&found))
+		require.Equal(it, u, found)
+	})
+
+	t.Run("Delete", func(it *testing.T) {
+		affected, err := tb.Delete(ctx, actions.Delete().Where(expr.Equal("ID", 2)))
+		require.NoError(it, err)
+		require.Equal(it, int64(1), affected)
+
+		var found sqliteUser
+		require.Equal(it, ErrNoRows, tb.FindOne(ctx, actions.FindOne().Where(expr.Equal("ID", 2))).Decode(&found))
+	})
+
+	t.Run("Insert maps", func(it *testing.T) {
+		// the first group is rolled back as the second group failed on duplicate primary key
+		_, err := tb.Insert(ctx, []map[string]interface{}{
+			{"ID": 10, "Name": "Alice"},
+			{"ID": 1, "Name": "Duplicate", "Age": 40},
+		})
+		require.Error(it, err)
+		var u sqliteUser
+		require.Equal(it, ErrNoRows, tb.FindOne(ctx, actions.FindOne().Where(expr.Equal("ID", 10))).Decode(&u))
+
+		result, err := tb.Insert(ctx, []map[string]interface{}{
+			{"ID": 10, "Name": "Alice"},
+			{"ID": 11, "Name": "Bob", "Age": 40},
+		})
+		require.NoError(it, err)
+		affected, err := result.RowsAffected()
+		require.NoError(it, err)
+		require.Equal(it, int64(2), affected)
+		_, err = tb.Delete(ctx, actions.Delete().Where(expr.In("ID", []int{10, 11})))
+		require.NoError(it, err)
+	})
+
+	// sqlite only supports adding and dropping the columns
+	t.Run("AlterTable", func(it *testing.T) {
+		plan, err := tb.PlanMigrate(ctx, sqliteUserV2{})
+		require.NoError(it, err)
+		require.Equal(it, []ColumnChange{{Action: ChangeAdd, Name: "Email", NewType: "TEXT"}}, plan.Columns)
+		require.NoError(it, tb.Migrate(ctx, sqliteUserV2{}))
+		require.Equal(it, []string{"ID", "Name", "Age", "Remark", "Email"}, listColumnNames(it, tb))
+
+		plan, err = tb.PlanUnsafeMigrate(ctx, sqliteUserV2{})
+		require.NoError(it, err)
+		require.True(it, plan.IsDestructive())
+		require.NoError(it, tb.UnsafeMigrate(ctx, sqliteUserV2{}))
+		require.Equal(it, []string{"ID", "Name", "Age", "Email"}, listColumnNames(it, tb))
+
+		var u sqliteUserV2
+		require.NoError(it, tb.FindOne(ctx, actions.FindOne().Where(expr.Equal("ID", 1))).Decode(&u))
+		require.Equal(it, sqliteUserV2{ID: 1, Name: "John", Age: 20}, u)
+	})
+}
+
+func listColumnNames(t *testing.T, tb *Table) []string {
+	cols, err := tb.ListColumns(context.Background())
+	require.NoError(t, err)
+	names := make([]string, len(cols))
+	for i, col := range cols {
+		names[i] = col.Name
+	}
+	return names
+}
@@ -3,10 +3,8 @@ package sqlike
 import (
 	"context"
 	"errors"
-	"reflect"
 	"strings"
 
-	"github.com/RevenueMonster/sqlike/reflext"
 	"github.com/RevenueMonster/sqlike/sql"
 
 	"github.com/RevenueMonster/sqlike/sql/codec"
@@ -170,17 +168,17 @@ func (tb Table) MustMigrate(ctx context.Context, entity interface{}) {
 
 // Migrate : migrate will create a new table follows by the definition of struct tag, alter when the table already exists
 func (tb *Table) Migrate(ctx context.Context, entity interface{}) error {
-	return tb.migrateOne(ctx, tb.client.cache, entity, false)
+	return tb.migrateOne(ctx, entity, false)
 }
 
 // UnsafeMigrate : unsafe migration will delete non-exist index and columns, beware when you use this
 func (tb *Table) UnsafeMigrate(ctx context.Context, entity interface{}) error {
-	return tb.migrateOne(ctx, tb.client.cache, entity, true)
+	return tb.migrateOne(ctx, entity, true)
 }
 
 // MustUnsafeMigrate : this will panic if it get error on unsafe migrate
 func (tb *Table) MustUnsafeMigrate(ctx context.Context, entity interface{}) {
-	err := tb.migrateOne(ctx, tb.client.cache, entity, true)
+	err := tb.migrateOne(ctx, entity, true)
 	if err != nil {
 		panic(err)
 	}
@@ -271,99 +269,74 @@ func (tb *Table) HasIndexByName(ctx context.Context, name string) (bool, error)
 	)
 }
 
-func (tb *Table) migrateOne(ctx context.Context, cache reflext.StructMapper, entity interface{}, unsafe bool) error {
-	v := reflext.ValueOf(entity)
-	if !v.IsValid() {
-		return ErrInvalidInput
-	}
-
-	t := reflext.Deref(v.Type())
-	if !reflext.IsKind(t, reflect.Struct) {
-		return ErrExpectedStruct
+// MigrateStatements : diff the struct against the existing table and return the statements to migrate (up) and revert (down) the changes without executing them,
+// both will be empty if there is no change. Both are generated from the same diff as `PlanMigrate`.
+func (tb *Table) MigrateStatements(ctx context.Context, entity interface{}) (up []string, down []string, err error) {
+	diff, err := tb.diffMigrate(ctx, entity, false)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	cdc := cache.CodecByType(t)
-	fields := skipColumns(cdc.Properties(), nil)
-	if len(fields) < 1 {
-		return ErrEmptyFields
+	up, err = tb.upStatements(diff)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	if !tb.Exists(ctx) {
-		return tb.createTable(ctx, fields)
+	down, err = tb.downStatements(diff)
+	if err != nil {
+		return nil, nil, err
 	}
+	return up, down, nil
+}
 
-	columns, err := tb.ListColumns(ctx)
+func (tb *Table) migrateOne(ctx context.Context, entity interface{}, unsafe bool) error {
+	diff, err := tb.diffMigrate(ctx, entity, unsafe)
 	if err != nil {
 		return err
 	}
-	idxs, err := tb.ListIndexes(ctx)
-	if err != nil {
+
+	if err := tb.register(diff.fields); err != nil {
 		return err
 	}
-	return tb.alterTable(ctx, fields, columns, idxs, unsafe)
+
+	// execute the same statements as the migration plan, nothing is executed if there is no changes
+	return tb.buildUpStatements(diff, func(stmt *sqlstmt.Statement) error {
+		_, err := sqldriver.Execute(
+			ctx,
+			tb.driver,
+			stmt,
+			tb.logger,
+		)
+		return err
+	})
 }
 
-func (tb *Table) createTable(ctx context.Context, fields []reflext.StructFielder) error {
+func (tb *Table) hasPrimaryKey(ctx context.Context) (bool, error) {
 	stmt := sqlstmt.AcquireStmt(tb.dialect)
 	defer sqlstmt.ReleaseStmt(stmt)
-	if err := tb.dialect.CreateTable(
-		stmt,
-		tb.dbName,
-		tb.name,
-		tb.pk,
-		tb.client.DriverInfo,
-		fields,
-	); err != nil {
-		return err
-	}
-	if _, err := sqldriver.Execute(
+	tb.dialect.HasPrimaryKey(stmt, tb.dbName, tb.name)
+	var count uint
+	if err := sqldriver.QueryRowContext(
 		ctx,
 		tb.driver,
 		stmt,
 		tb.logger,
-	); err != nil {
-		return err
+	).Scan(&count); err != nil {
+		return false, err
 	}
-	return nil
+	return count > 0, nil
 }
 
-func (tb *Table) alterTable(ctx context.Context, fields []reflext.StructFielder, columns []Column, indexs []Index, unsafe bool) error {
+func columnNames(columns []Column) []string {
 	cols := make([]string, len(columns))
 	for i, col := range columns {
 		cols[i] = col.Name
 	}
+	return cols
+}
+
+func indexNames(indexs []Index) []string {
 	idxs := make([]string, len(indexs))
 	for i, idx := range indexs {
 		idxs[i] = idx.Name
 	}
-	stmt := sqlstmt.AcquireStmt(tb.dialect)
-	defer sqlstmt.ReleaseStmt(stmt)
-	tb.dialect.HasPrimaryKey(stmt, tb.dbName, tb.name)
-	var count uint
-	if err := sqldriver.QueryRowContext(
-		ctx,
-		tb.driver,
-		stmt,
-		tb.logger,
-	).Scan(&count); err != nil {
-		return err
-	}
-	stmt.Reset()
-	if err := tb.dialect.AlterTable(
-		stmt,
-		tb.dbName, tb.name, tb.pk, count > 0,
-		tb.client.DriverInfo,
-		fields, cols, idxs, unsafe,
-	); err != nil {
-		return err
-	}
-	if _, err := sqldriver.Execute(
-		ctx,
-		tb.driver,
-		stmt,
-		tb.logger,
-	); err != nil {
-		return err
-	}
-	return nil
+	return idxs
 }
@@ -0,0 +1,75 @@
+package sqlike
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/RevenueMonster/sqlike/reflext"
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	dateType = reflect.TypeOf(civil.Date{})
+)
+
+// now : the current time of the client clock
+func (c *Client) now() time.Time {
+	if c == nil || c.clock == nil {
+		return time.Now().UTC()
+	}
+	return c.clock()
+}
+
+func (tb *Table) now() time.Time {
+	return tb.client.now()
+}
+
+// autoTimestamps : populate the `auto_update_time` fields with current time, the `auto_create_time` fields
+// will be populated as well when `create` is true and the field is zero value.
+func autoTimestamps(cache reflext.StructMapper, v reflect.Value, fields []reflext.StructFielder, now time.Time, create bool) error {
+	v = reflext.Indirect(v)
+	for _, sf := range fields {
+		tag := sf.Tag()
+		_, onUpdate := tag.LookUp("auto_update_time")
+		_, onCreate := tag.LookUp("auto_create_time")
+		if !onUpdate && !(create && onCreate) {
+			continue
+		}
+
+		if !v.CanAddr() {
+			return ErrUnaddressableEntity
+		}
+		fv := cache.FieldByIndexes(v, sf.Index())
+		if !onUpdate && !reflext.IsZero(fv) {
+			continue
+		}
+		if err := setTimestamp(fv, now); err != nil {
+			return fmt.Errorf("sqlike: invalid timestamp column %q, %w", sf.Name(), err)
+		}
+	}
+	return nil
+}
+
+// setTimestamp : support `time.Time`, `civil.Date`, `int64` (unix timestamp) and their pointers
+func setTimestamp(fv reflect.Value, now time.Time) error {
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		fv = fv.Elem()
+	}
+
+	switch {
+	case fv.Type() == timeType:
+		fv.Set(reflect.ValueOf(now))
+	case fv.Type() == dateType:
+		fv.Set(reflect.ValueOf(civil.DateOf(now)))
+	case fv.Kind() == reflect.Int64:
+		fv.SetInt(now.Unix())
+	default:
+		return fmt.Errorf("it must be either time.Time, civil.Date or int64, but got %v", fv.Type())
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package sqlike
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/stretchr/testify/require"
+)
+
+type timestampEntity struct {
+	ID        int64
+	CreatedAt time.Time  `sqlike:",auto_create_time"`
+	UpdatedAt *time.Time `sqlike:",auto_update_time"`
+	Date      civil.Date `sqlike:",auto_update_time"`
+	Unix      int64      `sqlike:",auto_create_time"`
+}
+
+func TestAutoTimestamps(t *testing.T) {
+	var (
+		now    = time.Date(2021, 5, 12, 8, 30, 0, 0, time.UTC)
+		cache  = reflext.DefaultMapper
+		fields = cache.CodecByType(reflect.TypeOf(timestampEntity{})).Properties()
+	)
+
+	t.Run("Client clock", func(it *testing.T) {
+		var c *Client
+		require.False(it, c.now().IsZero())
+
+		c = &Client{}
+		c.SetClock(func() time.Time { return now })
+		require.Equal(it, now, c.now())
+		require.Panics(it, func() { c.SetClock(nil) })
+	})
+
+	t.Run("create", func(it *testing.T) {
+		ent := timestampEntity{}
+		require.NoError(it, autoTimestamps(cache, reflect.ValueOf(&ent), fields, now, true))
+		require.Equal(it, now, ent.CreatedAt)
+		require.Equal(it, now, *ent.UpdatedAt)
+		require.Equal(it, civil.DateOf(now), ent.Date)
+		require.Equal(it, now.Unix(), ent.Unix)
+
+		// existing created time will be kept
+		later := now.Add(time.Hour)
+		require.NoError(it, autoTimestamps(cache, reflect.ValueOf(&ent), fields, later, true))
+		require.Equal(it, now, ent.CreatedAt)
+		require.Equal(it, later, *ent.UpdatedAt)
+	})
+
+	t.Run("update", func(it *testing.T) {
+		ent := timestampEntity{}
+		require.NoError(it, autoTimestamps(cache, reflect.ValueOf(&ent), fields, now, false))
+		require.True(it, ent.CreatedAt.IsZero())
+		require.Zero(it, ent.Unix)
+		require.Equal(it, now, *ent.UpdatedAt)
+	})
+
+	t.Run("invalid", func(it *testing.T) {
+		ent := struct {
+			CreatedAt string `sqlike:",auto_create_time"`
+		}{}
+		fields := cache.CodecByType(reflect.TypeOf(ent)).Properties()
+		require.Error(it, autoTimestamps(cache, reflect.ValueOf(&ent), fields, now, true))
+		require.Equal(it, ErrUnaddressableEntity, autoTimestamps(cache, reflect.ValueOf(ent), fields, now, true))
+	})
+}
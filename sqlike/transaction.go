@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strconv"
 
 	"github.com/RevenueMonster/sqlike/sql/codec"
 	"github.com/RevenueMonster/sqlike/sql/dialect"
@@ -38,6 +39,17 @@ type Transaction struct {
 	dialect dialect.Dialect
 	codec   codec.Codecer
 	logger  logs.Logger
+
+	// sequence of the savepoints, it's shared by the nested transactions as the name of savepoint must be unique
+	savepoints *int
+}
+
+const contextTransactionKey = "_sqlike_context_transaction"
+
+// transactionOf : the transaction which is opened by `RunInTransaction` or `BeginTransaction` of the context
+func transactionOf(ctx context.Context) *Transaction {
+	tx, _ := ctx.Value(contextTransactionKey).(*Transaction)
+	return tx
 }
 
 // Prepare : PrepareContext creates a prepared statement for use within a transaction.
@@ -95,6 +107,7 @@ func (tx *Transaction) QueryStmt(query interface{}) (*Result, error) {
 		return nil, err
 	}
 	rslt := new(Result)
+	rslt.ctx = tx
 	rslt.cache = tx.client.cache
 	rslt.codec = tx.codec
 	rslt.rows = rows
@@ -102,6 +115,38 @@ func (tx *Transaction) QueryStmt(query interface{}) (*Result, error) {
 	return rslt, rslt.err
 }
 
+// RunInTransaction : run the callback within a savepoint of the transaction, the changes of the callback are rolled back to the savepoint
+// if it returns error, and the transaction remains usable. The transaction is committed only when the outermost callback succeeded.
+func (tx *Transaction) RunInTransaction(cb txCallback) error {
+	if tx.savepoints == nil {
+		tx.savepoints = new(int)
+	}
+	*tx.savepoints++
+	name := "sqlike_sp_" + strconv.Itoa(*tx.savepoints)
+	if err := tx.execSavepoint("SAVEPOINT " + name); err != nil {
+		return err
+	}
+	if err := cb(tx); err != nil {
+		// the error of callback is more meaningful, the transaction will be rolled back anyway if the rollback of savepoint failed
+		tx.execSavepoint("ROLLBACK TO SAVEPOINT " + name)
+		return err
+	}
+	return tx.execSavepoint("RELEASE SAVEPOINT " + name)
+}
+
+func (tx *Transaction) execSavepoint(query string) error {
+	stmt := sqlstmt.AcquireStmt(tx.dialect)
+	defer sqlstmt.ReleaseStmt(stmt)
+	stmt.WriteString(query)
+	_, err := driver.Execute(
+		tx,
+		tx.driver,
+		stmt,
+		getLogger(tx.logger, true),
+	)
+	return err
+}
+
 // RollbackTransaction : Rollback aborts the transaction.
 func (tx *Transaction) RollbackTransaction() error {
 	return tx.driver.Rollback()
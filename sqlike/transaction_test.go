@@ -0,0 +1,356 @@
+package sqlike
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/codec"
+	"github.com/RevenueMonster/sqlike/sql/dialect/mysql"
+	"github.com/RevenueMonster/sqlike/sqlike/options"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+// txDriver : fake sql driver which records the executed statements, including the begin, commit and rollback of transaction
+type txDriver struct {
+	mu      sync.Mutex
+	queries []string
+	// the error of the statement which contains the key
+	errs map[string]error
+	// the single column rows of the query which contains the key
+	rows map[string][]driver.Value
+}
+
+func (d *txDriver) Open(name string) (driver.Conn, error) {
+	return &txConn{d: d}, nil
+}
+
+func (d *txDriver) exec(query string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queries = append(d.queries, query)
+	for k, err := range d.errs {
+		if strings.Contains(query, k) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *txDriver) history() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.queries...)
+}
+
+type txConn struct {
+	d *txDriver
+}
+
+func (c *txConn) Prepare(query string) (driver.Stmt, error) {
+	return &txStmt{d: c.d, query: query}, nil
+}
+
+func (c *txConn) Close() error { return nil }
+
+func (c *txConn) Begin() (driver.Tx, error) {
+	if err := c.d.exec("BEGIN"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *txConn) Commit() error { return c.d.exec("COMMIT") }
+
+func (c *txConn) Rollback() error { return c.d.exec("ROLLBACK") }
+
+type txStmt struct {
+	d     *txDriver
+	query string
+}
+
+func (s *txStmt) Close() error  { return nil }
+func (s *txStmt) NumInput() int { return -1 }
+
+func (s *txStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if err := s.d.exec(s.query); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *txStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if err := s.d.exec(s.query); err != nil {
+		return nil, err
+	}
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	for k, values := range s.d.rows {
+		if strings.Contains(s.query, k) {
+			return &valueRows{values: values}, nil
+		}
+	}
+	return emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string              { return []string{} }
+func (emptyRows) Close() error                   { return nil }
+func (emptyRows) Next(dest []driver.Value) error { return io.EOF }
+
+type valueRows struct {
+	values []driver.Value
+}
+
+func (r *valueRows) Columns() []string { return []string{"value"} }
+func (r *valueRows) Close() error      { return nil }
+func (r *valueRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0], r.values = r.values[0], r.values[1:]
+	return nil
+}
+
+var (
+	txDriverMu  sync.Mutex
+	txDriverSeq int
+)
+
+func newTxDatabase(t *testing.T) (*Database, *txDriver) {
+	txDriverMu.Lock()
+	txDriverSeq++
+	name := "sqlike_tx_" + strconv.Itoa(txDriverSeq)
+	txDriverMu.Unlock()
+
+	d := &txDriver{errs: make(map[string]error), rows: make(map[string][]driver.Value)}
+	sql.Register(name, d)
+	conn, err := sql.Open(name, "")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	client := &Client{DB: conn, cache: reflext.DefaultMapper, codec: codec.DefaultRegistry, dialect: mysql.New()}
+	return &Database{
+		name:    "db",
+		pk:      "$Key",
+		client:  client,
+		driver:  conn,
+		dialect: client.dialect,
+		codec:   client.codec,
+	}, d
+}
+
+func TestNestedTransaction(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("commit", func(it *testing.T) {
+		db, d := newTxDatabase(it)
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			if _, err := sess.Exec("UPDATE A"); err != nil {
+				return err
+			}
+			return db.RunInTransaction(sess, func(sess SessionContext) error {
+				_, err := sess.Exec("UPDATE B")
+				return err
+			})
+		})
+		require.NoError(it, err)
+		require.Equal(it, []string{
+			"BEGIN",
+			"UPDATE A",
+			"SAVEPOINT sqlike_sp_1",
+			"UPDATE B",
+			"RELEASE SAVEPOINT sqlike_sp_1",
+			"COMMIT",
+		}, d.history())
+	})
+
+	t.Run("rollback to savepoint", func(it *testing.T) {
+		db, d := newTxDatabase(it)
+		errNested := errors.New("nested error")
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			require.Equal(it, errNested, db.RunInTransaction(sess, func(sess SessionContext) error {
+				if _, err := sess.Exec("UPDATE A"); err != nil {
+					return err
+				}
+				return errNested
+			}))
+			// context derived from session is nested as well
+			ctx := context.WithValue(sess, contextResolutionKey, nil)
+			return db.RunInTransaction(ctx, func(sess SessionContext) error {
+				_, err := sess.Exec("UPDATE B")
+				return err
+			})
+		})
+		require.NoError(it, err)
+		require.Equal(it, []string{
+			"BEGIN",
+			"SAVEPOINT sqlike_sp_1",
+			"UPDATE A",
+			"ROLLBACK TO SAVEPOINT sqlike_sp_1",
+			"SAVEPOINT sqlike_sp_2",
+			"UPDATE B",
+			"RELEASE SAVEPOINT sqlike_sp_2",
+			"COMMIT",
+		}, d.history())
+	})
+
+	t.Run("outer transaction failed", func(it *testing.T) {
+		db, d := newTxDatabase(it)
+		errOuter := errors.New("outer error")
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			if err := db.RunInTransaction(sess, func(sess SessionContext) error {
+				return db.RunInTransaction(sess, func(sess SessionContext) error {
+					_, err := sess.Exec("UPDATE A")
+					return err
+				})
+			}); err != nil {
+				return err
+			}
+			return errOuter
+		})
+		require.Equal(it, errOuter, err)
+		require.Equal(it, []string{
+			"BEGIN",
+			"SAVEPOINT sqlike_sp_1",
+			"SAVEPOINT sqlike_sp_2",
+			"UPDATE A",
+			"RELEASE SAVEPOINT sqlike_sp_2",
+			"RELEASE SAVEPOINT sqlike_sp_1",
+			"ROLLBACK",
+		}, d.history())
+	})
+
+	t.Run("transaction of another client", func(it *testing.T) {
+		db, d := newTxDatabase(it)
+		other, _ := newTxDatabase(it)
+		err := other.RunInTransaction(ctx, func(sess SessionContext) error {
+			return db.RunInTransaction(sess, func(sess SessionContext) error {
+				return nil
+			})
+		})
+		require.NoError(it, err)
+		require.Equal(it, []string{"BEGIN", "COMMIT"}, d.history())
+	})
+}
+
+func TestRetryTransaction(t *testing.T) {
+	ctx := context.Background()
+	deadlock := &mysqldriver.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+	retry := options.Retry().SetMaxAttempts(3).SetBackoff(time.Millisecond, 0)
+
+	t.Run("retryDelay", func(it *testing.T) {
+		opt := options.Retry().SetBackoff(100*time.Millisecond, time.Second).SetJitter(0)
+		require.Equal(it, 100*time.Millisecond, retryDelay(opt, 1, 0.5))
+		require.Equal(it, 200*time.Millisecond, retryDelay(opt, 2, 0.5))
+		require.Equal(it, 800*time.Millisecond, retryDelay(opt, 4, 0.5))
+		require.Equal(it, time.Second, retryDelay(opt, 5, 0.5))
+		require.Equal(it, time.Second, retryDelay(opt, 100, 0.5))
+
+		opt.SetJitter(0.5)
+		require.Equal(it, 75*time.Millisecond, retryDelay(opt, 1, 0.5))
+		require.Equal(it, 50*time.Millisecond, retryDelay(opt, 1, 1))
+		require.Equal(it, 100*time.Millisecond, retryDelay(opt, 1, 0))
+
+		require.True(it, retryDelay(options.Retry().SetBackoff(time.Second, 0), 1000, 0) > 0)
+	})
+
+	t.Run("retry until succeeded", func(it *testing.T) {
+		db, d := newTxDatabase(it)
+		attempts := 0
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			attempts++
+			if attempts < 3 {
+				return fmt.Errorf("update: %w", deadlock)
+			}
+			return nil
+		}, options.Transaction().SetRetry(retry))
+		require.NoError(it, err)
+		require.Equal(it, 3, attempts)
+		require.Equal(it, []string{"BEGIN", "ROLLBACK", "BEGIN", "ROLLBACK", "BEGIN", "COMMIT"}, d.history())
+	})
+
+	t.Run("max attempts", func(it *testing.T) {
+		db, _ := newTxDatabase(it)
+		attempts := 0
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			attempts++
+			return deadlock
+		}, options.Transaction().SetRetry(retry))
+		require.Equal(it, deadlock, err)
+		require.Equal(it, 3, attempts)
+	})
+
+	t.Run("default max attempts", func(it *testing.T) {
+		db, _ := newTxDatabase(it)
+		attempts := 0
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			attempts++
+			return deadlock
+		}, options.Transaction().SetRetry(&options.RetryOptions{Backoff: time.Millisecond}))
+		require.Equal(it, deadlock, err)
+		require.Equal(it, options.DefaultRetryAttempts, attempts)
+	})
+
+	t.Run("non retryable error", func(it *testing.T) {
+		db, _ := newTxDatabase(it)
+		attempts := 0
+		errDuplicate := &mysqldriver.MySQLError{Number: 1062}
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			attempts++
+			return errDuplicate
+		}, options.Transaction().SetRetry(retry))
+		require.Equal(it, errDuplicate, err)
+		require.Equal(it, 1, attempts)
+	})
+
+	t.Run("retry on commit", func(it *testing.T) {
+		db, d := newTxDatabase(it)
+		d.errs["COMMIT"] = deadlock
+		attempts := 0
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			attempts++
+			if attempts == 2 {
+				delete(d.errs, "COMMIT")
+			}
+			return nil
+		}, options.Transaction().SetRetry(retry))
+		require.NoError(it, err)
+		require.Equal(it, 2, attempts)
+	})
+
+	t.Run("context cancelled", func(it *testing.T) {
+		db, _ := newTxDatabase(it)
+		ctx, cancel := context.WithCancel(ctx)
+		attempts := 0
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			attempts++
+			cancel()
+			return deadlock
+		}, options.Transaction().SetRetry(options.Retry().SetMaxAttempts(3).SetBackoff(time.Minute, 0)))
+		require.Equal(it, deadlock, err)
+		require.Equal(it, 1, attempts)
+	})
+
+	t.Run("without retry", func(it *testing.T) {
+		db, _ := newTxDatabase(it)
+		attempts := 0
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			attempts++
+			return deadlock
+		})
+		require.Equal(it, deadlock, err)
+		require.Equal(it, 1, attempts)
+	})
+}
@@ -67,6 +67,9 @@ func update(ctx context.Context, dbName, tbName string, driver sqldriver.Driver,
 	if len(act.Values) < 1 {
 		return 0, ErrNoValueUpdate
 	}
+	if !opt.NoResolution {
+		act.Conditions = withResolution(ctx, act.Conditions)
+	}
 	stmt := sqlstmt.AcquireStmt(dialect)
 	defer sqlstmt.ReleaseStmt(stmt)
 	if err := dialect.Update(stmt, act); err != nil {
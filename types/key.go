@@ -64,6 +64,19 @@ var (
 
 // DataType :
 func (k Key) DataType(t sqldriver.Info, sf reflext.StructFielder) columns.Column {
+	if t != nil {
+		switch t.DriverName() {
+		case "sqlite", "sqlite3":
+			// sqlite doesn't have charset and collation, the key is store as text affinity
+			return columns.Column{
+				Name:     sf.Name(),
+				DataType: "TEXT",
+				Type:     "TEXT",
+				Nullable: reflext.IsNullable(sf.Type()),
+			}
+		}
+	}
+
 	tag := sf.Tag()
 	size, charset, collate := "512", "latin1", "latin1_bin"
 	if v, ok := tag.LookUp("charset"); ok {
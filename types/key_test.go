@@ -10,6 +10,8 @@ import (
 
 	"github.com/RevenueMonster/sqlike/jsonb"
 	"github.com/RevenueMonster/sqlike/reflext"
+	"github.com/RevenueMonster/sqlike/sql/charset"
+	sqldriver "github.com/RevenueMonster/sqlike/sql/driver"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 )
@@ -68,6 +70,25 @@ func (field) IsEmbedded() bool {
 
 var _ reflext.StructFielder = (*field)(nil)
 
+type driverInfo string
+
+// DriverName :
+func (d driverInfo) DriverName() string {
+	return string(d)
+}
+
+// Charset :
+func (driverInfo) Charset() charset.Code {
+	return charset.UTF8MB4
+}
+
+// Collate :
+func (driverInfo) Collate() string {
+	return ""
+}
+
+var _ sqldriver.Info = (*driverInfo)(nil)
+
 func TestKey(t *testing.T) {
 	var (
 		k   *Key
@@ -91,6 +112,21 @@ func TestKey(t *testing.T) {
 		require.True(it, col.Nullable)
 	})
 
+	t.Run("DataType with sqlite", func(it *testing.T) {
+		k := new(Key)
+		col := k.DataType(driverInfo("sqlite3"), field{
+			name: "Key",
+			t:    reflect.TypeOf(k),
+		})
+
+		require.Equal(it, "Key", col.Name)
+		require.Equal(it, "TEXT", col.DataType)
+		require.Equal(it, "TEXT", col.Type)
+		require.Nil(it, col.Charset)
+		require.Nil(it, col.Collation)
+		require.True(it, col.Nullable)
+	})
+
 	t.Run("String and GoString", func(it *testing.T) {
 		k := NameKey("Child", "!@#$%^&*()'dajhdkhas", NameKey("Parent", "{}|askjdhkashy8q,L:\"><<", nil))
 
@@ -14,7 +14,27 @@ import (
 type Set []string
 
 // DataType :
-func (s Set) DataType(_ sqldriver.Info, sf reflext.StructFielder) columns.Column {
+func (s Set) DataType(info sqldriver.Info, sf reflext.StructFielder) columns.Column {
+	if info != nil {
+		// postgres and sqlite doesn't have `SET` data type, store it as comma separated string
+		switch info.DriverName() {
+		case "postgres":
+			return columns.Column{
+				Name:     sf.Name(),
+				Type:     "VARCHAR(191)",
+				DataType: "VARCHAR",
+				Nullable: reflext.IsNullable(sf.Type()),
+			}
+		case "sqlite", "sqlite3":
+			return columns.Column{
+				Name:     sf.Name(),
+				Type:     "TEXT",
+				DataType: "TEXT",
+				Nullable: reflext.IsNullable(sf.Type()),
+			}
+		}
+	}
+
 	charset, collate := "utf8mb4", "utf8mb4_0900_ai_ci"
 	blr := util.AcquireString()
 	defer util.ReleaseString(blr)
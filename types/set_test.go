@@ -27,6 +27,20 @@ func TestSet(t *testing.T) {
 		require.Equal(it, "utf8mb4_0900_ai_ci", *col.Collation)
 	})
 
+	t.Run("DataType with sqlite", func(it *testing.T) {
+		col := set.DataType(driverInfo("sqlite"), field{
+			name: "Set",
+			t:    reflect.TypeOf(set),
+			null: true,
+		})
+
+		require.Equal(it, "Set", col.Name)
+		require.Equal(it, "TEXT", col.DataType)
+		require.Equal(it, "TEXT", col.Type)
+		require.Nil(it, col.Charset)
+		require.True(it, col.Nullable)
+	})
+
 	t.Run("driver.Valuer with nil value", func(it *testing.T) {
 		var set Set
 		v, err := set.Value()